@@ -1,81 +1,172 @@
 package approval
 
 import (
-	"github.com/thoas/go-funk"
+	"math/big"
+	"slices"
+
+	"github.com/This-Is-Prince/votingSystemGo/utils"
+	"github.com/This-Is-Prince/votingSystemGo/voting"
 )
 
 type ApprovalVote struct {
-	Choice  []int     `json:"choice"`
-	Balance float64   `json:"balance"`
-	Scores  []float64 `json:"scores"`
+	Choice       []int      `json:"choice"`
+	Balance      float64    `json:"balance"`
+	Scores       []float64  `json:"scores"`
+	ExactBalance *big.Rat   `json:"exactBalance,omitempty"`
+	ExactScores  []*big.Rat `json:"exactScores,omitempty"`
+	BalanceUnits *big.Int   `json:"balanceUnits,omitempty"`
+	ScoresUnits  []*big.Int `json:"scoresUnits,omitempty"`
+	Voter        string     `json:"voter,omitempty"`
+	Created      int64      `json:"created,omitempty"`
 }
 
 type ApprovalVoting struct {
-	Choices    []string       `json:"choices"`
-	Votes      []ApprovalVote `json:"votes"`
-	Strategies []interface{}  `json:"strategies"`
+	Choices    []string          `json:"choices"`
+	Votes      []ApprovalVote    `json:"votes"`
+	Strategies []voting.Strategy `json:"strategies"`
+	Decimals   int               `json:"decimals,omitempty"`
 }
 
-func IsValidChoice(voteChoice []int, proposalChoices []string) bool {
+var _ voting.VotingSystem = (*ApprovalVoting)(nil)
+
+func (v ApprovalVote) GetChoice() interface{} {
+	return v.Choice
+}
+
+func (v ApprovalVote) GetBalance() float64 {
+	return v.Balance
+}
+
+func (v ApprovalVote) GetScores() []float64 {
+	return v.Scores
+}
+
+func (v ApprovalVote) GetVoter() string {
+	return v.Voter
+}
+
+func (v ApprovalVote) GetCreated() int64 {
+	return v.Created
+}
+
+func (v ApprovalVote) Supports(choice int) bool {
+	return slices.Contains(v.Choice, choice)
+}
+
+func ValidateChoice(voteChoice []int, proposalChoices []string) error {
 	voteChoiceSet := make(map[int]struct{})
-	filteredVoteChoice := funk.FilterInt(voteChoice, func(c int) bool {
+	for _, c := range voteChoice {
+		if c <= 0 || c > len(proposalChoices) {
+			return voting.NewValidationError(voting.CodeChoiceOutOfRange, "choice %d is not between 1 and %d", c, len(proposalChoices))
+		}
+		if _, ok := voteChoiceSet[c]; ok {
+			return voting.NewValidationError(voting.CodeDuplicateChoice, "choice %d is approved more than once", c)
+		}
 		voteChoiceSet[c] = struct{}{}
-		return c > 0 && c <= len(proposalChoices)
-	})
+	}
 
-	return len(voteChoice) == len(filteredVoteChoice) && len(voteChoice) == len(voteChoiceSet)
+	return nil
+}
+
+func IsValidChoice(voteChoice []int, proposalChoices []string) bool {
+	return ValidateChoice(voteChoice, proposalChoices) == nil
+}
+
+func (v *ApprovalVoting) GetChoices() []string {
+	return v.Choices
+}
+
+func (v *ApprovalVoting) GetStrategies() []voting.Strategy {
+	return v.Strategies
+}
+
+func (v *ApprovalVoting) GetDecimals() int {
+	return v.Decimals
+}
+
+func (v *ApprovalVoting) GetVotes() []voting.Vote {
+	return voting.ToVotes(v.Votes)
+}
+
+func (v *ApprovalVoting) Deduplicate(policy voting.DedupPolicy) []voting.Vote {
+	votes, superseded := voting.Deduplicate(v.Votes, policy)
+	v.Votes = votes
+	return voting.ToVotes(superseded)
+}
+
+func (v *ApprovalVoting) IsValidVote(vote voting.Vote) bool {
+	return v.ValidateVote(vote) == nil
+}
+
+func (v *ApprovalVoting) ValidateVote(vote voting.Vote) error {
+	approvalVote, ok := vote.(ApprovalVote)
+	if !ok {
+		return voting.NewValidationError(voting.CodeInvalidVoteType, "expected %T, got %T", ApprovalVote{}, vote)
+	}
+
+	return v.validateVote(approvalVote)
+}
+
+func (v *ApprovalVoting) validateVote(vote ApprovalVote) error {
+	if err := voting.ValidateStrategies(v.Strategies); err != nil {
+		return err
+	}
+
+	if err := ValidateChoice(vote.Choice, v.Choices); err != nil {
+		return err
+	}
+
+	return voting.ValidateAmounts(vote.Balance, vote.Scores, vote.ExactBalance, vote.ExactScores, vote.BalanceUnits, vote.ScoresUnits, len(v.Strategies))
+}
+
+func (v *ApprovalVoting) power(vote ApprovalVote) (float64, []float64) {
+	balance, scores := voting.FloatAmounts(vote.Balance, vote.Scores, vote.ExactBalance, vote.ExactScores, vote.BalanceUnits, vote.ScoresUnits, v.Decimals)
+	return voting.EffectivePower(v.Strategies, balance, scores)
 }
 
 func (v *ApprovalVoting) GetValidVotes() []ApprovalVote {
-	return funk.Filter(v.Votes, func(vote ApprovalVote) bool {
-		return IsValidChoice(vote.Choice, v.Choices)
-	}).([]ApprovalVote)
+	return utils.Filter(v.Votes, func(vote ApprovalVote) bool {
+		return v.validateVote(vote) == nil
+	})
 }
 
 func (v *ApprovalVoting) GetScoresTotal() float64 {
-	return funk.Reduce(v.Votes, func(acc float64, vote ApprovalVote) float64 {
-		return acc + vote.Balance
-	}, float64(0)).(float64)
+	scoresTotal := utils.Accumulator{}
+	for _, vote := range v.GetValidVotes() {
+		balance, _ := v.power(vote)
+		scoresTotal.Add(balance)
+	}
+	return scoresTotal.Float64()
 }
 
 func (v *ApprovalVoting) GetScores() []float64 {
-	scores := []float64{}
-
-	for range v.Choices {
-		scores = append(scores, float64(0))
-	}
+	scores := make([]utils.Accumulator, len(v.Choices))
 
 	for _, vote := range v.Votes {
-		if IsValidChoice(vote.Choice, v.Choices) {
+		if v.validateVote(vote) == nil {
 			for _, choice := range vote.Choice {
-				scores[choice-1] = scores[choice-1] + vote.Balance
+				balance, _ := v.power(vote)
+				scores[choice-1].Add(balance)
 			}
 		}
 	}
 
-	return scores
+	return utils.AccumulatorsToFloats(scores)
 }
 
 func (v *ApprovalVoting) GetScoresByStrategy() [][]float64 {
-	scoresByStrategy := [][]float64{}
-
-	for range v.Choices {
-		scores := []float64{}
-		for range v.Strategies {
-			scores = append(scores, float64(0))
-		}
-		scoresByStrategy = append(scoresByStrategy, scores)
-	}
+	scoresByStrategy := utils.NewAccumulatorMatrix(len(v.Choices), len(v.Strategies))
 
 	for _, vote := range v.Votes {
-		if IsValidChoice(vote.Choice, v.Choices) {
+		if v.validateVote(vote) == nil {
 			for _, choice := range vote.Choice {
-				for idx, score := range vote.Scores {
-					scoresByStrategy[choice-1][idx] = scoresByStrategy[choice-1][idx] + score
+				_, scores := v.power(vote)
+				for idx, score := range scores {
+					scoresByStrategy[choice-1][idx].Add(score)
 				}
 			}
 		}
 	}
 
-	return scoresByStrategy
+	return utils.AccumulatorMatrixToFloats(scoresByStrategy)
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/This-Is-Prince/votingSystemGo/utils"
+	"github.com/This-Is-Prince/votingSystemGo/voting"
 )
 
 func TestApprovalVoting(t *testing.T) {
@@ -20,7 +21,7 @@ func TestApprovalVoting(t *testing.T) {
 			Scores:  []float64{float64(10.812822710153798), float64(2)},
 		},
 	}
-	strategies := []interface{}{1, 2}
+	strategies := []voting.Strategy{{Name: "erc20-balance-of"}, {Name: "delegation"}}
 	approvalVoting := ApprovalVoting{
 		Choices:    choices,
 		Votes:      votes,
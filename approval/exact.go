@@ -0,0 +1,59 @@
+package approval
+
+import (
+	"math/big"
+
+	"github.com/This-Is-Prince/votingSystemGo/utils"
+	"github.com/This-Is-Prince/votingSystemGo/voting"
+)
+
+var _ voting.ExactVotingSystem = (*ApprovalVoting)(nil)
+
+func (v ApprovalVote) GetExactBalance(decimals int) *big.Rat {
+	return utils.ExactValue(v.BalanceUnits, v.ExactBalance, v.Balance, decimals)
+}
+
+func (v ApprovalVote) GetExactScores(decimals int) []*big.Rat {
+	return utils.ExactValues(v.ScoresUnits, v.ExactScores, v.Scores, decimals)
+}
+
+func (v *ApprovalVoting) GetScoresTotalExact() *big.Rat {
+	scoresTotal := new(big.Rat)
+	for _, vote := range v.GetValidVotes() {
+		balance, _ := v.exactPower(vote)
+		scoresTotal.Add(scoresTotal, balance)
+	}
+	return scoresTotal
+}
+
+func (v *ApprovalVoting) GetScoresExact() []*big.Rat {
+	scores := utils.NewRats(len(v.Choices))
+
+	for _, vote := range v.GetValidVotes() {
+		balance, _ := v.exactPower(vote)
+		for _, choice := range vote.Choice {
+			scores[choice-1].Add(scores[choice-1], balance)
+		}
+	}
+
+	return scores
+}
+
+func (v *ApprovalVoting) GetScoresByStrategyExact() [][]*big.Rat {
+	scoresByStrategy := utils.NewRatMatrix(len(v.Choices), len(v.Strategies))
+
+	for _, vote := range v.GetValidVotes() {
+		_, scores := v.exactPower(vote)
+		for _, choice := range vote.Choice {
+			for idx, score := range scores {
+				scoresByStrategy[choice-1][idx].Add(scoresByStrategy[choice-1][idx], score)
+			}
+		}
+	}
+
+	return scoresByStrategy
+}
+
+func (v *ApprovalVoting) exactPower(vote ApprovalVote) (*big.Rat, []*big.Rat) {
+	return voting.EffectivePowerExact(v.Strategies, vote.GetExactBalance(v.Decimals), vote.GetExactScores(v.Decimals))
+}
@@ -0,0 +1,77 @@
+package approval
+
+import (
+	"encoding/json"
+
+	"github.com/This-Is-Prince/votingSystemGo/voting"
+)
+
+// Tallier keeps running approval scores, so adding, removing or
+// replacing a vote costs O(choices × strategies) and the scores match a full
+// tally of the same votes.
+type Tallier struct {
+	voting.RunningTally[ApprovalVote]
+}
+
+var _ voting.Tallier = (*Tallier)(nil)
+
+var _ voting.Streamer = (*ApprovalVoting)(nil)
+
+func NewTallier(choices []string, strategies []voting.Strategy) *Tallier {
+	return (&ApprovalVoting{Choices: choices, Strategies: strategies}).newTallier()
+}
+
+// newTallier returns an empty Tallier that counts votes as the proposal
+// does, in its decimals.
+func (v *ApprovalVoting) newTallier() *Tallier {
+	proposal := *v
+	proposal.Votes = nil
+	return &Tallier{voting.NewRunningTally(len(proposal.Choices), len(proposal.Strategies), proposal.ValidateVote, proposal.add)}
+}
+
+// NewTallier returns a Tallier seeded with the valid votes of the proposal.
+func (v *ApprovalVoting) NewTallier() voting.Tallier {
+	t := v.newTallier()
+	for _, vote := range v.GetValidVotes() {
+		t.Count(vote)
+	}
+	return t
+}
+
+// DecodeVote reads the next approval vote from dec.
+func (v *ApprovalVoting) DecodeVote(dec *json.Decoder) (voting.Vote, error) {
+	var vote ApprovalVote
+	if err := dec.Decode(&vote); err != nil {
+		return nil, err
+	}
+	return vote, nil
+}
+
+// TallyParallel tallies the valid votes of the proposal on the given number of
+// workers and returns the merged Tallier. The scores match GetScores and
+// GetScoresByStrategy exactly.
+func (v *ApprovalVoting) TallyParallel(workers int) *Tallier {
+	return voting.ParallelTally(v.Votes, workers, func() *Tallier {
+		return v.newTallier()
+	}, func(t *Tallier, vote ApprovalVote) {
+		if v.validateVote(vote) == nil {
+			t.Count(vote)
+		}
+	}, (*Tallier).Merge)
+}
+
+// Merge adds the votes tallied by other into t.
+func (t *Tallier) Merge(other *Tallier) {
+	t.RunningTally.Merge(&other.RunningTally)
+}
+
+func (v *ApprovalVoting) add(t *voting.RunningTally[ApprovalVote], vote ApprovalVote, sign float64) {
+	balance, scores := v.power(vote)
+	t.ScoresTotal.Add(sign * balance)
+	for _, choice := range vote.Choice {
+		t.Scores[choice-1].Add(sign * balance)
+		for idx, score := range scores {
+			t.ScoresByStrategy[choice-1][idx].Add(sign * score)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package approval
+
+import (
+	"math/big"
+
+	"github.com/This-Is-Prince/votingSystemGo/utils"
+	"github.com/This-Is-Prince/votingSystemGo/voting"
+)
+
+var _ voting.UnitsVotingSystem = (*ApprovalVoting)(nil)
+
+func (v ApprovalVote) GetBalanceUnits(decimals int) *big.Int {
+	return utils.UnitsValue(v.BalanceUnits, v.ExactBalance, v.Balance, decimals)
+}
+
+func (v ApprovalVote) GetScoresUnits(decimals int) []*big.Int {
+	return utils.UnitsValues(v.ScoresUnits, v.ExactScores, v.Scores, decimals)
+}
+
+func (v *ApprovalVoting) GetScoresTotalUnits() *big.Int {
+	scoresTotal := new(big.Int)
+	for _, vote := range v.GetValidVotes() {
+		balance, _ := v.unitsPower(vote)
+		scoresTotal.Add(scoresTotal, balance)
+	}
+	return scoresTotal
+}
+
+func (v *ApprovalVoting) GetScoresUnits() []*big.Int {
+	scores := utils.NewInts(len(v.Choices))
+
+	for _, vote := range v.GetValidVotes() {
+		balance, _ := v.unitsPower(vote)
+		for _, choice := range vote.Choice {
+			scores[choice-1].Add(scores[choice-1], balance)
+		}
+	}
+
+	return scores
+}
+
+func (v *ApprovalVoting) GetScoresByStrategyUnits() [][]*big.Int {
+	scoresByStrategy := utils.NewIntMatrix(len(v.Choices), len(v.Strategies))
+
+	for _, vote := range v.GetValidVotes() {
+		_, scores := v.unitsPower(vote)
+		for _, choice := range vote.Choice {
+			for idx, score := range scores {
+				scoresByStrategy[choice-1][idx].Add(scoresByStrategy[choice-1][idx], score)
+			}
+		}
+	}
+
+	return scoresByStrategy
+}
+
+func (v *ApprovalVoting) unitsPower(vote ApprovalVote) (*big.Int, []*big.Int) {
+	return voting.EffectivePowerUnits(v.Strategies, v.Decimals, vote.GetBalanceUnits(v.Decimals), vote.GetScoresUnits(v.Decimals))
+}
@@ -0,0 +1,209 @@
+package basic
+
+import (
+	"math/big"
+
+	"github.com/This-Is-Prince/votingSystemGo/utils"
+	"github.com/This-Is-Prince/votingSystemGo/voting"
+)
+
+const (
+	For     = 1
+	Against = 2
+	Abstain = 3
+)
+
+var Choices = []string{"For", "Against", "Abstain"}
+
+type BasicVote struct {
+	Choice       int        `json:"choice"`
+	Balance      float64    `json:"balance"`
+	Scores       []float64  `json:"scores"`
+	ExactBalance *big.Rat   `json:"exactBalance,omitempty"`
+	ExactScores  []*big.Rat `json:"exactScores,omitempty"`
+	BalanceUnits *big.Int   `json:"balanceUnits,omitempty"`
+	ScoresUnits  []*big.Int `json:"scoresUnits,omitempty"`
+	Voter        string     `json:"voter,omitempty"`
+	Created      int64      `json:"created,omitempty"`
+}
+
+// BasicVoting is a For/Against/Abstain vote. Quorum is the minimum total
+// balance that must take part, abstentions included. Threshold is the
+// minimum share of For in For+Against needed to pass; when it is zero the
+// proposal needs a simple majority, i.e. more For than Against.
+type BasicVoting struct {
+	Votes      []BasicVote       `json:"votes"`
+	Strategies []voting.Strategy `json:"strategies"`
+	Decimals   int               `json:"decimals,omitempty"`
+	Quorum     float64           `json:"quorum"`
+	Threshold  float64           `json:"threshold"`
+}
+
+type Outcome struct {
+	For           float64 `json:"for"`
+	Against       float64 `json:"against"`
+	Abstain       float64 `json:"abstain"`
+	Participation float64 `json:"participation"`
+	Approval      float64 `json:"approval"`
+	QuorumReached bool    `json:"quorumReached"`
+	Passed        bool    `json:"passed"`
+}
+
+var _ voting.VotingSystem = (*BasicVoting)(nil)
+
+func (v BasicVote) GetChoice() interface{} {
+	return v.Choice
+}
+
+func (v BasicVote) GetBalance() float64 {
+	return v.Balance
+}
+
+func (v BasicVote) GetScores() []float64 {
+	return v.Scores
+}
+
+func (v BasicVote) GetVoter() string {
+	return v.Voter
+}
+
+func (v BasicVote) GetCreated() int64 {
+	return v.Created
+}
+
+func (v BasicVote) Supports(choice int) bool {
+	return v.Choice == choice
+}
+
+func ValidateChoice(voteChoice int) error {
+	if voteChoice < For || voteChoice > Abstain {
+		return voting.NewValidationError(voting.CodeChoiceOutOfRange, "choice %d is not between %d and %d", voteChoice, For, Abstain)
+	}
+
+	return nil
+}
+
+func IsValidChoice(voteChoice int) bool {
+	return ValidateChoice(voteChoice) == nil
+}
+
+func (v *BasicVoting) GetChoices() []string {
+	return Choices
+}
+
+func (v *BasicVoting) GetStrategies() []voting.Strategy {
+	return v.Strategies
+}
+
+func (v *BasicVoting) GetDecimals() int {
+	return v.Decimals
+}
+
+func (v *BasicVoting) GetVotes() []voting.Vote {
+	return voting.ToVotes(v.Votes)
+}
+
+func (v *BasicVoting) Deduplicate(policy voting.DedupPolicy) []voting.Vote {
+	votes, superseded := voting.Deduplicate(v.Votes, policy)
+	v.Votes = votes
+	return voting.ToVotes(superseded)
+}
+
+func (v *BasicVoting) IsValidVote(vote voting.Vote) bool {
+	return v.ValidateVote(vote) == nil
+}
+
+func (v *BasicVoting) ValidateVote(vote voting.Vote) error {
+	basicVote, ok := vote.(BasicVote)
+	if !ok {
+		return voting.NewValidationError(voting.CodeInvalidVoteType, "expected %T, got %T", BasicVote{}, vote)
+	}
+
+	return v.validateVote(basicVote)
+}
+
+func (v *BasicVoting) validateVote(vote BasicVote) error {
+	if err := voting.ValidateStrategies(v.Strategies); err != nil {
+		return err
+	}
+
+	if err := ValidateChoice(vote.Choice); err != nil {
+		return err
+	}
+
+	return voting.ValidateAmounts(vote.Balance, vote.Scores, vote.ExactBalance, vote.ExactScores, vote.BalanceUnits, vote.ScoresUnits, len(v.Strategies))
+}
+
+func (v *BasicVoting) power(vote BasicVote) (float64, []float64) {
+	balance, scores := voting.FloatAmounts(vote.Balance, vote.Scores, vote.ExactBalance, vote.ExactScores, vote.BalanceUnits, vote.ScoresUnits, v.Decimals)
+	return voting.EffectivePower(v.Strategies, balance, scores)
+}
+
+func (v *BasicVoting) GetValidVotes() []BasicVote {
+	return utils.Filter(v.Votes, func(vote BasicVote) bool {
+		return v.validateVote(vote) == nil
+	})
+}
+
+func (v *BasicVoting) GetScoresTotal() float64 {
+	scoresTotal := utils.Accumulator{}
+	for _, vote := range v.GetValidVotes() {
+		balance, _ := v.power(vote)
+		scoresTotal.Add(balance)
+	}
+	return scoresTotal.Float64()
+}
+
+func (v *BasicVoting) GetScores() []float64 {
+	scores := make([]utils.Accumulator, len(Choices))
+
+	for _, vote := range v.Votes {
+		choice := vote.Choice
+		if v.validateVote(vote) == nil {
+			balance, _ := v.power(vote)
+			scores[choice-1].Add(balance)
+		}
+	}
+
+	return utils.AccumulatorsToFloats(scores)
+}
+
+func (v *BasicVoting) GetScoresByStrategy() [][]float64 {
+	scoresByStrategy := utils.NewAccumulatorMatrix(len(Choices), len(v.Strategies))
+
+	for _, vote := range v.Votes {
+		choice := vote.Choice
+		if v.validateVote(vote) == nil {
+			_, scores := v.power(vote)
+			for idx, score := range scores {
+				scoresByStrategy[choice-1][idx].Add(score)
+			}
+		}
+	}
+
+	return utils.AccumulatorMatrixToFloats(scoresByStrategy)
+}
+
+func (v *BasicVoting) GetOutcome() Outcome {
+	scores := v.GetScores()
+	outcome := Outcome{
+		For:     scores[For-1],
+		Against: scores[Against-1],
+		Abstain: scores[Abstain-1],
+	}
+
+	outcome.Participation = outcome.For + outcome.Against + outcome.Abstain
+	if decisive := outcome.For + outcome.Against; decisive > 0 {
+		outcome.Approval = outcome.For / decisive
+	}
+
+	outcome.QuorumReached = outcome.Participation > 0 && outcome.Participation >= v.Quorum
+
+	if v.Threshold == 0 {
+		outcome.Passed = outcome.QuorumReached && outcome.For > outcome.Against
+	} else {
+		outcome.Passed = outcome.QuorumReached && outcome.Approval >= v.Threshold
+	}
+
+	return outcome
+}
@@ -0,0 +1,139 @@
+package basic
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/This-Is-Prince/votingSystemGo/utils"
+	"github.com/This-Is-Prince/votingSystemGo/voting"
+)
+
+func TestBasicVoting(t *testing.T) {
+	votes := []BasicVote{
+		{
+			Choice:  For,
+			Balance: float64(6),
+			Scores:  []float64{float64(4), float64(2)},
+		},
+		{
+			Choice:  Against,
+			Balance: float64(3),
+			Scores:  []float64{float64(3), float64(0)},
+		},
+		{
+			Choice:  Abstain,
+			Balance: float64(11),
+			Scores:  []float64{float64(1), float64(10)},
+		},
+		{
+			Choice:  4,
+			Balance: float64(5),
+			Scores:  []float64{float64(5), float64(0)},
+		},
+	}
+	basicVoting := BasicVoting{
+		Votes:      votes,
+		Strategies: []voting.Strategy{{Name: "erc20-balance-of"}, {Name: "delegation"}},
+		Quorum:     float64(20),
+		Threshold:  float64(2) / float64(3),
+	}
+
+	validVotes := basicVoting.GetValidVotes()
+	if len(validVotes) != len(votes)-1 {
+		t.Errorf("Expected %d valid votes, got %d", len(votes)-1, len(validVotes))
+	}
+
+	expectedScores := []float64{float64(6), float64(3), float64(11)}
+	scores := basicVoting.GetScores()
+	for i, score := range scores {
+		if !utils.FloatEqual(score, expectedScores[i]) {
+			t.Errorf("Expected score %f for choice %s, got %f", expectedScores[i], Choices[i], score)
+		}
+	}
+
+	expectedScoresByStrategy := [][]float64{
+		{float64(4), float64(2)},
+		{float64(3), float64(0)},
+		{float64(1), float64(10)},
+	}
+	for i, scoreByStrategy := range basicVoting.GetScoresByStrategy() {
+		for j, score := range scoreByStrategy {
+			if !utils.FloatEqual(score, expectedScoresByStrategy[i][j]) {
+				t.Errorf("Expected score %f got %f", expectedScoresByStrategy[i][j], score)
+			}
+		}
+	}
+
+	outcome := basicVoting.GetOutcome()
+	if !utils.FloatEqual(outcome.Participation, float64(20)) {
+		t.Errorf("Expected participation %f, got %f", float64(20), outcome.Participation)
+	}
+
+	if !utils.FloatEqual(outcome.Approval, float64(2)/float64(3)) {
+		t.Errorf("Expected approval %f, got %f", float64(2)/float64(3), outcome.Approval)
+	}
+
+	if !outcome.QuorumReached || !outcome.Passed {
+		t.Errorf("Expected quorum reached and passed, got %+v", outcome)
+	}
+
+	basicVoting.Quorum = float64(21)
+	if outcome := basicVoting.GetOutcome(); outcome.QuorumReached || outcome.Passed {
+		t.Errorf("Expected quorum not reached, got %+v", outcome)
+	}
+
+	basicVoting.Quorum = float64(0)
+	basicVoting.Threshold = float64(0.7)
+	if outcome := basicVoting.GetOutcome(); outcome.Passed {
+		t.Errorf("Expected proposal to fail the threshold, got %+v", outcome)
+	}
+
+	basicVoting.Threshold = float64(0)
+	basicVoting.Votes = append(basicVoting.Votes, BasicVote{Choice: Against, Balance: float64(3), Scores: []float64{float64(3), float64(0)}})
+	if outcome := basicVoting.GetOutcome(); outcome.Passed {
+		t.Errorf("Expected a tied simple majority to fail, got %+v", outcome)
+	}
+}
+
+func TestTallier(t *testing.T) {
+	votes := []BasicVote{
+		{Choice: For, Balance: 0.1, Scores: []float64{0.03, 0.07}},
+		{Choice: Against, Balance: 0.2, Scores: []float64{0.1, 0.1}},
+		{Choice: For, Balance: 0.3, Scores: []float64{0.2, 0.1}},
+	}
+	strategies := []voting.Strategy{{Name: "erc20-balance-of"}, {Name: "delegation"}}
+
+	tallier := NewTallier(strategies)
+	for _, vote := range votes {
+		if err := tallier.AddVote(vote); err != nil {
+			t.Fatalf("Expected vote %v to be added, got %v", vote, err)
+		}
+	}
+	if err := tallier.RemoveVote(votes[1]); err != nil {
+		t.Fatalf("Expected vote to be removed, got %v", err)
+	}
+	replacement := BasicVote{Choice: Abstain, Balance: 0.7, Scores: []float64{0.35, 0.35}}
+	if err := tallier.ReplaceVote(votes[0], replacement); err != nil {
+		t.Fatalf("Expected vote to be replaced, got %v", err)
+	}
+	if err := tallier.AddVote(BasicVote{Choice: 4, Balance: 1, Scores: []float64{0.5, 0.5}}); err == nil {
+		t.Errorf("Expected invalid vote to be rejected")
+	}
+
+	basicVoting := BasicVoting{Votes: []BasicVote{replacement, votes[2]}, Strategies: strategies}
+	if tallier.GetScoresTotal() != basicVoting.GetScoresTotal() {
+		t.Errorf("Expected scores total %v, got %v", basicVoting.GetScoresTotal(), tallier.GetScoresTotal())
+	}
+	if !reflect.DeepEqual(tallier.GetScores(), basicVoting.GetScores()) {
+		t.Errorf("Expected scores %v, got %v", basicVoting.GetScores(), tallier.GetScores())
+	}
+	if !reflect.DeepEqual(tallier.GetScoresByStrategy(), basicVoting.GetScoresByStrategy()) {
+		t.Errorf("Expected scores by strategy %v, got %v", basicVoting.GetScoresByStrategy(), tallier.GetScoresByStrategy())
+	}
+	if !reflect.DeepEqual(basicVoting.NewTallier().GetScores(), basicVoting.GetScores()) {
+		t.Errorf("Expected seeded tallier to match the proposal scores")
+	}
+	if !reflect.DeepEqual(basicVoting.TallyParallel(2).GetScoresByStrategy(), basicVoting.GetScoresByStrategy()) {
+		t.Errorf("Expected parallel tally to match the proposal scores by strategy")
+	}
+}
@@ -0,0 +1,256 @@
+package condorcet
+
+import (
+	"github.com/This-Is-Prince/votingSystemGo/utils"
+	"github.com/This-Is-Prince/votingSystemGo/voting"
+)
+
+// CondorcetVote has no ExactBalance, BalanceUnits or ScoresUnits, and
+// CondorcetVoting no Decimals: pairwise counts run on float balances, and
+// base-unit fields in a document are dropped when it is decoded.
+type CondorcetVote struct {
+	Choice  []int     `json:"choice"`
+	Balance float64   `json:"balance"`
+	Scores  []float64 `json:"scores"`
+	Voter   string    `json:"voter,omitempty"`
+	Created int64     `json:"created,omitempty"`
+}
+
+// CondorcetVoting compares every pair of choices on ranked ballots. A ballot
+// prefers each ranked choice to those ranked below it and to every choice it
+// leaves out; choices it leaves out are tied with each other. Every count
+// needs all the ballots, so it has no Tallier and cannot be streamed.
+type CondorcetVoting struct {
+	Choices    []string          `json:"choices"`
+	Votes      []CondorcetVote   `json:"votes"`
+	Strategies []voting.Strategy `json:"strategies"`
+}
+
+// Result is everything needed to audit a Condorcet count. Matrix[i][j] is
+// the balance preferring choice i+1 to choice j+1. CondorcetWinner is 0
+// when no choice beats every other, and Cycle reports whether the majority
+// preferences loop. Rankings list tiers of 1-based choices, best first;
+// choices in one tier are tied.
+type Result struct {
+	Matrix             [][]float64 `json:"matrix"`
+	CondorcetWinner    int         `json:"condorcetWinner"`
+	Cycle              bool        `json:"cycle"`
+	StrongestPaths     [][]float64 `json:"strongestPaths"`
+	SchulzeRanking     [][]int     `json:"schulzeRanking"`
+	Pairs              []Pair      `json:"pairs"`
+	RankedPairsRanking [][]int     `json:"rankedPairsRanking"`
+}
+
+var (
+	_ voting.VotingSystem     = (*CondorcetVoting)(nil)
+	_ voting.PercentageSystem = (*CondorcetVoting)(nil)
+)
+
+func (v CondorcetVote) GetChoice() interface{} {
+	return v.Choice
+}
+
+func (v CondorcetVote) GetBalance() float64 {
+	return v.Balance
+}
+
+func (v CondorcetVote) GetScores() []float64 {
+	return v.Scores
+}
+
+func (v CondorcetVote) GetVoter() string {
+	return v.Voter
+}
+
+func (v CondorcetVote) GetCreated() int64 {
+	return v.Created
+}
+
+func (v CondorcetVote) Supports(choice int) bool {
+	return len(v.Choice) > 0 && v.Choice[0] == choice
+}
+
+func ValidateChoice(voteChoice []int, proposalChoices []string) error {
+	if len(voteChoice) == 0 {
+		return voting.NewValidationError(voting.CodeEmptyChoice, "no choice is ranked")
+	}
+
+	voteChoiceSet := make(map[int]struct{})
+	for _, c := range voteChoice {
+		if c <= 0 || c > len(proposalChoices) {
+			return voting.NewValidationError(voting.CodeChoiceOutOfRange, "choice %d is not between 1 and %d", c, len(proposalChoices))
+		}
+		if _, ok := voteChoiceSet[c]; ok {
+			return voting.NewValidationError(voting.CodeDuplicateChoice, "choice %d is ranked more than once", c)
+		}
+		voteChoiceSet[c] = struct{}{}
+	}
+
+	return nil
+}
+
+func IsValidChoice(voteChoice []int, proposalChoices []string) bool {
+	return ValidateChoice(voteChoice, proposalChoices) == nil
+}
+
+func (v *CondorcetVoting) GetChoices() []string {
+	return v.Choices
+}
+
+func (v *CondorcetVoting) GetStrategies() []voting.Strategy {
+	return v.Strategies
+}
+
+func (v *CondorcetVoting) GetVotes() []voting.Vote {
+	return voting.ToVotes(v.Votes)
+}
+
+func (v *CondorcetVoting) Deduplicate(policy voting.DedupPolicy) []voting.Vote {
+	votes, superseded := voting.Deduplicate(v.Votes, policy)
+	v.Votes = votes
+	return voting.ToVotes(superseded)
+}
+
+func (v *CondorcetVoting) IsValidVote(vote voting.Vote) bool {
+	return v.ValidateVote(vote) == nil
+}
+
+func (v *CondorcetVoting) ValidateVote(vote voting.Vote) error {
+	condorcetVote, ok := vote.(CondorcetVote)
+	if !ok {
+		return voting.NewValidationError(voting.CodeInvalidVoteType, "expected %T, got %T", CondorcetVote{}, vote)
+	}
+
+	return v.validateVote(condorcetVote)
+}
+
+func (v *CondorcetVoting) validateVote(vote CondorcetVote) error {
+	if err := voting.ValidateStrategies(v.Strategies); err != nil {
+		return err
+	}
+
+	if err := ValidateChoice(vote.Choice, v.Choices); err != nil {
+		return err
+	}
+
+	return voting.ValidatePower(vote.Balance, vote.Scores, len(v.Strategies))
+}
+
+func (v *CondorcetVoting) power(vote CondorcetVote) (float64, []float64) {
+	return voting.EffectivePower(v.Strategies, vote.Balance, vote.Scores)
+}
+
+func (v *CondorcetVoting) GetValidVotes() []CondorcetVote {
+	return utils.Filter(v.Votes, func(vote CondorcetVote) bool {
+		return v.validateVote(vote) == nil
+	})
+}
+
+func (v *CondorcetVoting) GetScoresTotal() float64 {
+	scoresTotal := utils.Accumulator{}
+	for _, vote := range v.GetValidVotes() {
+		balance, _ := v.power(vote)
+		scoresTotal.Add(balance)
+	}
+	return scoresTotal.Float64()
+}
+
+// GetPairwiseMatrix returns the balance-weighted pairwise preferences:
+// entry [i][j] is the balance of the ballots preferring choice i+1 to
+// choice j+1.
+func (v *CondorcetVoting) GetPairwiseMatrix() [][]float64 {
+	return v.pairwiseMatrix(func(vote CondorcetVote) float64 {
+		balance, _ := v.power(vote)
+		return balance
+	})
+}
+
+// GetPairwiseMatrixByStrategy returns one pairwise matrix per strategy,
+// weighting each ballot by its score for that strategy.
+func (v *CondorcetVoting) GetPairwiseMatrixByStrategy() [][][]float64 {
+	matrices := [][][]float64{}
+	for idx := range v.Strategies {
+		matrices = append(matrices, v.pairwiseMatrix(func(vote CondorcetVote) float64 {
+			_, scores := v.power(vote)
+			return scores[idx]
+		}))
+	}
+	return matrices
+}
+
+func (v *CondorcetVoting) pairwiseMatrix(weight func(vote CondorcetVote) float64) [][]float64 {
+	matrix := utils.NewAccumulatorMatrix(len(v.Choices), len(v.Choices))
+
+	for _, vote := range v.GetValidVotes() {
+		w := weight(vote)
+		ranked := make([]bool, len(v.Choices))
+		for _, choice := range vote.Choice {
+			for other := range v.Choices {
+				if other != choice-1 && !ranked[other] {
+					matrix[choice-1][other].Add(w)
+				}
+			}
+			ranked[choice-1] = true
+		}
+	}
+
+	return utils.AccumulatorMatrixToFloats(matrix)
+}
+
+func (v *CondorcetVoting) GetCondorcetWinner() int {
+	return CondorcetWinner(v.GetPairwiseMatrix())
+}
+
+func (v *CondorcetVoting) GetResult() Result {
+	matrix := v.GetPairwiseMatrix()
+	paths, schulzeRanking := Schulze(matrix)
+	pairs, rankedPairsRanking := RankedPairs(matrix)
+
+	return Result{
+		Matrix:             matrix,
+		CondorcetWinner:    CondorcetWinner(matrix),
+		Cycle:              HasCycle(matrix),
+		StrongestPaths:     paths,
+		SchulzeRanking:     schulzeRanking,
+		Pairs:              pairs,
+		RankedPairsRanking: rankedPairsRanking,
+	}
+}
+
+// GetScores returns, for each choice, how many other choices it beats on
+// Schulze strongest paths, so the highest score is the Schulze winner.
+func (v *CondorcetVoting) GetScores() []float64 {
+	paths, _ := Schulze(v.GetPairwiseMatrix())
+	return schulzeWins(paths)
+}
+
+// GetPercentages returns the share of the other choices each choice beats,
+// so the Condorcet winner has 1. Dividing wins by the balance would mean
+// nothing.
+func (v *CondorcetVoting) GetPercentages() []float64 {
+	percentages := v.GetScores()
+	for idx := range percentages {
+		if len(v.Choices) > 1 {
+			percentages[idx] /= float64(len(v.Choices) - 1)
+		}
+	}
+	return percentages
+}
+
+// GetScoresByStrategy returns GetScores computed separately from each
+// strategy's pairwise matrix.
+func (v *CondorcetVoting) GetScoresByStrategy() [][]float64 {
+	scoresByStrategy := [][]float64{}
+	for range v.Choices {
+		scoresByStrategy = append(scoresByStrategy, make([]float64, len(v.Strategies)))
+	}
+
+	for idx, matrix := range v.GetPairwiseMatrixByStrategy() {
+		paths, _ := Schulze(matrix)
+		for choice, wins := range schulzeWins(paths) {
+			scoresByStrategy[choice][idx] = wins
+		}
+	}
+
+	return scoresByStrategy
+}
@@ -0,0 +1,174 @@
+package condorcet
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/This-Is-Prince/votingSystemGo/utils"
+	"github.com/This-Is-Prince/votingSystemGo/voting"
+)
+
+func TestCondorcetVoting(t *testing.T) {
+	choices := []string{"First", "Second", "Third"}
+	votes := []CondorcetVote{
+		{
+			Choice:  []int{1, 2, 3},
+			Balance: float64(4),
+			Scores:  []float64{float64(1), float64(3)},
+		},
+		{
+			Choice:  []int{2, 1},
+			Balance: float64(3),
+			Scores:  []float64{float64(3), float64(0)},
+		},
+		{
+			Choice:  []int{3, 2, 1},
+			Balance: float64(2),
+			Scores:  []float64{float64(2), float64(0)},
+		},
+		{
+			Choice:  []int{3, 3},
+			Balance: float64(7),
+			Scores:  []float64{float64(7), float64(0)},
+		},
+	}
+	condorcetVoting := CondorcetVoting{
+		Choices:    choices,
+		Votes:      votes,
+		Strategies: []voting.Strategy{{Name: "erc20-balance-of"}, {Name: "delegation"}},
+	}
+
+	validVotes := condorcetVoting.GetValidVotes()
+	if len(validVotes) != len(votes)-1 {
+		t.Errorf("Expected %d valid votes, got %d", len(votes)-1, len(validVotes))
+	}
+
+	expectedScoresTotal := float64(9)
+	scoresTotal := condorcetVoting.GetScoresTotal()
+	if !utils.FloatEqual(scoresTotal, expectedScoresTotal) {
+		t.Errorf("Expected scores total to be %f, got %f", expectedScoresTotal, scoresTotal)
+	}
+
+	// The second ballot leaves the third choice out, so it prefers both
+	// ranked choices to it.
+	expectedMatrix := [][]float64{{0, 4, 7}, {5, 0, 7}, {2, 2, 0}}
+	if matrix := condorcetVoting.GetPairwiseMatrix(); !reflect.DeepEqual(matrix, expectedMatrix) {
+		t.Errorf("Expected pairwise matrix %v, got %v", expectedMatrix, matrix)
+	}
+
+	if winner := condorcetVoting.GetCondorcetWinner(); winner != 2 {
+		t.Errorf("Expected choice %d to be the Condorcet winner, got %d", 2, winner)
+	}
+
+	result := condorcetVoting.GetResult()
+	if result.Cycle {
+		t.Errorf("Expected no cycle")
+	}
+	expectedRanking := [][]int{{2}, {1}, {3}}
+	if !reflect.DeepEqual(result.SchulzeRanking, expectedRanking) || !reflect.DeepEqual(result.RankedPairsRanking, expectedRanking) {
+		t.Errorf("Expected rankings %v, got %v and %v", expectedRanking, result.SchulzeRanking, result.RankedPairsRanking)
+	}
+
+	expectedScores := []float64{1, 2, 0}
+	if scores := condorcetVoting.GetScores(); !reflect.DeepEqual(scores, expectedScores) {
+		t.Errorf("Expected scores %v, got %v", expectedScores, scores)
+	}
+
+	expectedPercentages := []float64{0.5, 1, 0}
+	if percentages := voting.NewResult(&condorcetVoting).Percentages; !reflect.DeepEqual(percentages, expectedPercentages) {
+		t.Errorf("Expected percentages %v, got %v", expectedPercentages, percentages)
+	}
+
+	// By the first strategy the first ballot weighs 1 against 3, so the
+	// second choice beats the first; by the second only the first ballot
+	// counts.
+	expectedScoresByStrategy := [][]float64{{1, 2}, {2, 1}, {0, 0}}
+	if scores := condorcetVoting.GetScoresByStrategy(); !reflect.DeepEqual(scores, expectedScoresByStrategy) {
+		t.Errorf("Expected scores by strategy %v, got %v", expectedScoresByStrategy, scores)
+	}
+}
+
+func TestSchulzeAndRankedPairs(t *testing.T) {
+	// The five-choice example from Markus Schulze's paper, 45 voters.
+	ballots := []struct {
+		count  float64
+		choice []int
+	}{
+		{5, []int{1, 3, 2, 5, 4}},
+		{5, []int{1, 4, 5, 3, 2}},
+		{8, []int{2, 5, 4, 1, 3}},
+		{3, []int{3, 1, 2, 5, 4}},
+		{7, []int{3, 1, 5, 2, 4}},
+		{2, []int{3, 2, 1, 4, 5}},
+		{7, []int{4, 3, 5, 2, 1}},
+		{8, []int{5, 2, 1, 4, 3}},
+	}
+	condorcetVoting := CondorcetVoting{Choices: []string{"A", "B", "C", "D", "E"}}
+	for _, ballot := range ballots {
+		condorcetVoting.Votes = append(condorcetVoting.Votes, CondorcetVote{Choice: ballot.choice, Balance: ballot.count, Scores: []float64{}})
+	}
+
+	result := condorcetVoting.GetResult()
+
+	expectedMatrix := [][]float64{
+		{0, 20, 26, 30, 22},
+		{25, 0, 16, 33, 18},
+		{19, 29, 0, 17, 24},
+		{15, 12, 28, 0, 14},
+		{23, 27, 21, 31, 0},
+	}
+	if !reflect.DeepEqual(result.Matrix, expectedMatrix) {
+		t.Errorf("Expected pairwise matrix %v, got %v", expectedMatrix, result.Matrix)
+	}
+
+	if result.CondorcetWinner != 0 || !result.Cycle {
+		t.Errorf("Expected a cycle and no Condorcet winner, got winner %d and cycle %v", result.CondorcetWinner, result.Cycle)
+	}
+
+	expectedPaths := [][]float64{
+		{0, 28, 28, 30, 24},
+		{25, 0, 28, 33, 24},
+		{25, 29, 0, 29, 24},
+		{25, 28, 28, 0, 24},
+		{25, 28, 28, 31, 0},
+	}
+	if !reflect.DeepEqual(result.StrongestPaths, expectedPaths) {
+		t.Errorf("Expected strongest paths %v, got %v", expectedPaths, result.StrongestPaths)
+	}
+
+	expectedSchulze := [][]int{{5}, {1}, {3}, {2}, {4}}
+	if !reflect.DeepEqual(result.SchulzeRanking, expectedSchulze) {
+		t.Errorf("Expected Schulze ranking %v, got %v", expectedSchulze, result.SchulzeRanking)
+	}
+
+	expectedLocked := map[[2]int]bool{
+		{2, 4}: true, {5, 4}: true, {1, 4}: true, {3, 2}: true, {4, 3}: false,
+		{5, 2}: true, {1, 3}: true, {2, 1}: false, {3, 5}: true, {5, 1}: false,
+	}
+	if len(result.Pairs) != len(expectedLocked) {
+		t.Fatalf("Expected %d majorities, got %d", len(expectedLocked), len(result.Pairs))
+	}
+	for idx, pair := range result.Pairs {
+		if idx > 0 && pair.Support > result.Pairs[idx-1].Support {
+			t.Errorf("Expected majorities strongest first, got %v", result.Pairs)
+		}
+		if locked, ok := expectedLocked[[2]int{pair.Winner, pair.Loser}]; !ok || locked != pair.Locked {
+			t.Errorf("Expected majority %d over %d locked %v, got %v", pair.Winner, pair.Loser, locked, pair.Locked)
+		}
+	}
+
+	expectedRankedPairs := [][]int{{1}, {3}, {5}, {2}, {4}}
+	if !reflect.DeepEqual(result.RankedPairsRanking, expectedRankedPairs) {
+		t.Errorf("Expected Ranked Pairs ranking %v, got %v", expectedRankedPairs, result.RankedPairsRanking)
+	}
+
+	winners := voting.GetWinners(condorcetVoting.GetScores())
+	if len(winners) != 1 || winners[0] != 5 {
+		t.Errorf("Expected the Schulze winner %d to have the top score, got %v", 5, winners)
+	}
+
+	tied := [][]float64{{0, 5}, {5, 0}}
+	if _, ranking := Schulze(tied); !reflect.DeepEqual(ranking, [][]int{{1, 2}}) {
+		t.Errorf("Expected a tied pair to share a tier, got %v", ranking)
+	}
+}
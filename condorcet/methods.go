@@ -0,0 +1,217 @@
+package condorcet
+
+import "sort"
+
+// Pair is a majority of one choice over another. Support is the balance
+// preferring Winner and Opposition the balance preferring Loser. Locked
+// reports whether Ranked Pairs kept the pair.
+type Pair struct {
+	Winner     int     `json:"winner"`
+	Loser      int     `json:"loser"`
+	Support    float64 `json:"support"`
+	Opposition float64 `json:"opposition"`
+	Locked     bool    `json:"locked"`
+}
+
+// CondorcetWinner returns the 1-based choice that a majority prefers to
+// every other choice, or 0 when there is none.
+func CondorcetWinner(matrix [][]float64) int {
+	for i := range matrix {
+		wins := true
+		for j := range matrix {
+			if i != j && matrix[i][j] <= matrix[j][i] {
+				wins = false
+				break
+			}
+		}
+		if wins {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// HasCycle reports whether the majority preferences contain a cycle, such
+// as A beating B, B beating C and C beating A.
+func HasCycle(matrix [][]float64) bool {
+	beats := func(i, j int) bool {
+		return matrix[i][j] > matrix[j][i]
+	}
+
+	for i := range matrix {
+		if reaches(len(matrix), beats, i, i) {
+			return true
+		}
+	}
+	return false
+}
+
+// reaches reports whether there is a path of one or more edges from start
+// to target.
+func reaches(n int, edge func(i, j int) bool, start int, target int) bool {
+	visited := make([]bool, n)
+	stack := []int{start}
+	for len(stack) > 0 {
+		i := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for j := 0; j < n; j++ {
+			if !edge(i, j) {
+				continue
+			}
+			if j == target {
+				return true
+			}
+			if !visited[j] {
+				visited[j] = true
+				stack = append(stack, j)
+			}
+		}
+	}
+	return false
+}
+
+// Schulze returns the strongest path strengths between every pair of
+// choices and the Schulze ranking. A link from i to j has the strength of
+// i's majority over j, measured in winning votes; a path is as strong as its
+// weakest link.
+func Schulze(matrix [][]float64) ([][]float64, [][]int) {
+	n := len(matrix)
+	paths := [][]float64{}
+	for i := 0; i < n; i++ {
+		paths = append(paths, make([]float64, n))
+		for j := 0; j < n; j++ {
+			if i != j && matrix[i][j] > matrix[j][i] {
+				paths[i][j] = matrix[i][j]
+			}
+		}
+	}
+
+	for k := 0; k < n; k++ {
+		for i := 0; i < n; i++ {
+			if i == k {
+				continue
+			}
+			for j := 0; j < n; j++ {
+				if j == i || j == k {
+					continue
+				}
+				if through := min(paths[i][k], paths[k][j]); through > paths[i][j] {
+					paths[i][j] = through
+				}
+			}
+		}
+	}
+
+	ranking := rank(n, func(i, j int) bool {
+		return paths[i][j] > paths[j][i]
+	})
+	return paths, ranking
+}
+
+func schulzeWins(paths [][]float64) []float64 {
+	wins := make([]float64, len(paths))
+	for i := range paths {
+		for j := range paths {
+			if i != j && paths[i][j] > paths[j][i] {
+				wins[i]++
+			}
+		}
+	}
+	return wins
+}
+
+// RankedPairs returns every majority, strongest first, marking those that
+// Ranked Pairs (Tideman) locked in, and the resulting ranking. Majorities
+// are ordered by winning votes, then by the smaller opposition, then by the
+// lower winner and loser, so the count is deterministic. A majority is
+// locked unless it would close a cycle with the majorities already locked.
+func RankedPairs(matrix [][]float64) ([]Pair, [][]int) {
+	n := len(matrix)
+	pairs := []Pair{}
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if i != j && matrix[i][j] > matrix[j][i] {
+				pairs = append(pairs, Pair{Winner: i + 1, Loser: j + 1, Support: matrix[i][j], Opposition: matrix[j][i]})
+			}
+		}
+	}
+
+	sort.SliceStable(pairs, func(a, b int) bool {
+		if pairs[a].Support != pairs[b].Support {
+			return pairs[a].Support > pairs[b].Support
+		}
+		if pairs[a].Opposition != pairs[b].Opposition {
+			return pairs[a].Opposition < pairs[b].Opposition
+		}
+		if pairs[a].Winner != pairs[b].Winner {
+			return pairs[a].Winner < pairs[b].Winner
+		}
+		return pairs[a].Loser < pairs[b].Loser
+	})
+
+	locked := make([][]bool, n)
+	for i := range locked {
+		locked[i] = make([]bool, n)
+	}
+	edge := func(i, j int) bool {
+		return locked[i][j]
+	}
+
+	for idx, pair := range pairs {
+		winner, loser := pair.Winner-1, pair.Loser-1
+		if reaches(n, edge, loser, winner) {
+			continue
+		}
+		locked[winner][loser] = true
+		pairs[idx].Locked = true
+	}
+
+	return pairs, rank(n, edge)
+}
+
+// rank orders n choices into tiers, best first: each tier holds the
+// remaining choices that no remaining choice beats.
+func rank(n int, beats func(i, j int) bool) [][]int {
+	ranking := [][]int{}
+	remaining := make([]bool, n)
+	for i := range remaining {
+		remaining[i] = true
+	}
+
+	for left := n; left > 0; {
+		tier := []int{}
+		for i := 0; i < n; i++ {
+			if !remaining[i] {
+				continue
+			}
+			beaten := false
+			for j := 0; j < n; j++ {
+				if remaining[j] && j != i && beats(j, i) {
+					beaten = true
+					break
+				}
+			}
+			if !beaten {
+				tier = append(tier, i+1)
+			}
+		}
+
+		// Both relations are acyclic, so a tier is never empty; guard
+		// anyway so a bad relation cannot loop forever.
+		if len(tier) == 0 {
+			for i := 0; i < n; i++ {
+				if remaining[i] {
+					tier = append(tier, i+1)
+				}
+			}
+		}
+
+		for _, choice := range tier {
+			remaining[choice-1] = false
+		}
+		left -= len(tier)
+		ranking = append(ranking, tier)
+	}
+
+	return ranking
+}
@@ -0,0 +1,261 @@
+package positional
+
+import (
+	"math"
+
+	"github.com/This-Is-Prince/votingSystemGo/utils"
+	"github.com/This-Is-Prince/votingSystemGo/voting"
+)
+
+// Method picks the points a ballot gives each rank.
+type Method string
+
+const (
+	// Borda gives n-1 points to the first of n choices, n-2 to the second,
+	// and so on down to 0.
+	Borda Method = "borda"
+	// Dowdall gives 1/r points to the choice ranked r.
+	Dowdall Method = "dowdall"
+	// Custom takes the points for each rank from Weights.
+	Custom Method = "custom"
+)
+
+// PositionalVote only carries a float Balance and Scores. Unlike the vote
+// types of the earlier packages it has no exact or base-unit amounts, so a
+// "balanceUnits" field is ignored when decoding; convert to tokens first.
+type PositionalVote struct {
+	Choice  []int     `json:"choice"`
+	Balance float64   `json:"balance"`
+	Scores  []float64 `json:"scores"`
+	Voter   string    `json:"voter,omitempty"`
+	Created int64     `json:"created,omitempty"`
+}
+
+// PositionalVoting scores ranked ballots by position: each ballot gives the
+// choice at rank r the method's points for r, times its balance. Choices
+// left off a partial ballot get no points. Method defaults to Borda. There
+// is no Tallier yet, so a positional proposal cannot be streamed or tallied
+// in parallel.
+type PositionalVoting struct {
+	Choices    []string          `json:"choices"`
+	Votes      []PositionalVote  `json:"votes"`
+	Strategies []voting.Strategy `json:"strategies"`
+	Method     Method            `json:"method,omitempty"`
+	Weights    []float64         `json:"weights,omitempty"`
+}
+
+var (
+	_ voting.VotingSystem     = (*PositionalVoting)(nil)
+	_ voting.PercentageSystem = (*PositionalVoting)(nil)
+)
+
+func (v PositionalVote) GetChoice() interface{} {
+	return v.Choice
+}
+
+func (v PositionalVote) GetBalance() float64 {
+	return v.Balance
+}
+
+func (v PositionalVote) GetScores() []float64 {
+	return v.Scores
+}
+
+func (v PositionalVote) GetVoter() string {
+	return v.Voter
+}
+
+func (v PositionalVote) GetCreated() int64 {
+	return v.Created
+}
+
+func (v PositionalVote) Supports(choice int) bool {
+	return len(v.Choice) > 0 && v.Choice[0] == choice
+}
+
+func ValidateChoice(voteChoice []int, proposalChoices []string) error {
+	if len(voteChoice) == 0 {
+		return voting.NewValidationError(voting.CodeEmptyChoice, "no choice is ranked")
+	}
+
+	voteChoiceSet := make(map[int]struct{})
+	for _, c := range voteChoice {
+		if c <= 0 || c > len(proposalChoices) {
+			return voting.NewValidationError(voting.CodeChoiceOutOfRange, "choice %d is not between 1 and %d", c, len(proposalChoices))
+		}
+		if _, ok := voteChoiceSet[c]; ok {
+			return voting.NewValidationError(voting.CodeDuplicateChoice, "choice %d is ranked more than once", c)
+		}
+		voteChoiceSet[c] = struct{}{}
+	}
+
+	return nil
+}
+
+func IsValidChoice(voteChoice []int, proposalChoices []string) bool {
+	return ValidateChoice(voteChoice, proposalChoices) == nil
+}
+
+// PositionWeights returns the points for each of n ranks under method.
+// Custom weights beyond the last given rank are 0.
+func PositionWeights(method Method, weights []float64, n int) ([]float64, error) {
+	points := make([]float64, n)
+
+	switch method {
+	case Borda, "":
+		for r := range points {
+			points[r] = float64(n - 1 - r)
+		}
+	case Dowdall:
+		for r := range points {
+			points[r] = 1 / float64(r+1)
+		}
+	case Custom:
+		if len(weights) == 0 {
+			return nil, voting.NewValidationError(voting.CodeInvalidVote, "custom method has no weights")
+		}
+		if len(weights) > n {
+			return nil, voting.NewValidationError(voting.CodeInvalidVote, "%d weights for %d choices", len(weights), n)
+		}
+		for r, weight := range weights {
+			if math.IsNaN(weight) || math.IsInf(weight, 0) || weight < 0 {
+				return nil, voting.NewValidationError(voting.CodeInvalidVote, "weight %v for rank %d is not a finite, non-negative number", weight, r+1)
+			}
+		}
+		copy(points, weights)
+	default:
+		return nil, voting.NewValidationError(voting.CodeInvalidVote, "unknown positional method %q", method)
+	}
+
+	return points, nil
+}
+
+// GetWeights returns the points for each rank of the proposal.
+func (v *PositionalVoting) GetWeights() ([]float64, error) {
+	return PositionWeights(v.Method, v.Weights, len(v.Choices))
+}
+
+func (v *PositionalVoting) GetChoices() []string {
+	return v.Choices
+}
+
+func (v *PositionalVoting) GetStrategies() []voting.Strategy {
+	return v.Strategies
+}
+
+func (v *PositionalVoting) GetVotes() []voting.Vote {
+	return voting.ToVotes(v.Votes)
+}
+
+func (v *PositionalVoting) Deduplicate(policy voting.DedupPolicy) []voting.Vote {
+	votes, superseded := voting.Deduplicate(v.Votes, policy)
+	v.Votes = votes
+	return voting.ToVotes(superseded)
+}
+
+func (v *PositionalVoting) IsValidVote(vote voting.Vote) bool {
+	return v.ValidateVote(vote) == nil
+}
+
+func (v *PositionalVoting) ValidateVote(vote voting.Vote) error {
+	positionalVote, ok := vote.(PositionalVote)
+	if !ok {
+		return voting.NewValidationError(voting.CodeInvalidVoteType, "expected %T, got %T", PositionalVote{}, vote)
+	}
+
+	return v.validateVote(positionalVote)
+}
+
+// validateVote also rejects every vote of a proposal whose method cannot
+// score a ballot or whose strategies are invalid, so a misconfigured
+// proposal counts nothing.
+func (v *PositionalVoting) validateVote(vote PositionalVote) error {
+	if _, err := v.GetWeights(); err != nil {
+		return err
+	}
+
+	if err := voting.ValidateStrategies(v.Strategies); err != nil {
+		return err
+	}
+
+	if err := ValidateChoice(vote.Choice, v.Choices); err != nil {
+		return err
+	}
+
+	return voting.ValidatePower(vote.Balance, vote.Scores, len(v.Strategies))
+}
+
+func (v *PositionalVoting) power(vote PositionalVote) (float64, []float64) {
+	return voting.EffectivePower(v.Strategies, vote.Balance, vote.Scores)
+}
+
+func (v *PositionalVoting) GetValidVotes() []PositionalVote {
+	return utils.Filter(v.Votes, func(vote PositionalVote) bool {
+		return v.validateVote(vote) == nil
+	})
+}
+
+// GetScoresTotal returns the balance of the valid votes, not the points
+// they handed out.
+func (v *PositionalVoting) GetScoresTotal() float64 {
+	scoresTotal := utils.Accumulator{}
+	for _, vote := range v.GetValidVotes() {
+		balance, _ := v.power(vote)
+		scoresTotal.Add(balance)
+	}
+	return scoresTotal.Float64()
+}
+
+func (v *PositionalVoting) GetScores() []float64 {
+	scores := make([]utils.Accumulator, len(v.Choices))
+	weights, err := v.GetWeights()
+	if err != nil {
+		return utils.AccumulatorsToFloats(scores)
+	}
+
+	for _, vote := range v.GetValidVotes() {
+		balance, _ := v.power(vote)
+		for rank, choice := range vote.Choice {
+			scores[choice-1].Add(weights[rank] * balance)
+		}
+	}
+
+	return utils.AccumulatorsToFloats(scores)
+}
+
+// GetPercentages returns each choice's share of the points handed out,
+// since a ballot gives out more points than its balance.
+func (v *PositionalVoting) GetPercentages() []float64 {
+	percentages := v.GetScores()
+	total := utils.Accumulator{}
+	for _, score := range percentages {
+		total.Add(score)
+	}
+
+	sum := total.Float64()
+	for idx := range percentages {
+		if sum != 0 {
+			percentages[idx] /= sum
+		}
+	}
+	return percentages
+}
+
+func (v *PositionalVoting) GetScoresByStrategy() [][]float64 {
+	scoresByStrategy := utils.NewAccumulatorMatrix(len(v.Choices), len(v.Strategies))
+	weights, err := v.GetWeights()
+	if err != nil {
+		return utils.AccumulatorMatrixToFloats(scoresByStrategy)
+	}
+
+	for _, vote := range v.GetValidVotes() {
+		_, scores := v.power(vote)
+		for rank, choice := range vote.Choice {
+			for idx, score := range scores {
+				scoresByStrategy[choice-1][idx].Add(weights[rank] * score)
+			}
+		}
+	}
+
+	return utils.AccumulatorMatrixToFloats(scoresByStrategy)
+}
@@ -0,0 +1,125 @@
+package positional
+
+import (
+	"testing"
+
+	"github.com/This-Is-Prince/votingSystemGo/utils"
+	"github.com/This-Is-Prince/votingSystemGo/voting"
+)
+
+func TestPositionalVoting(t *testing.T) {
+	choices := []string{"First", "Second", "Third"}
+	votes := []PositionalVote{
+		{
+			Choice:  []int{1, 2, 3},
+			Balance: float64(4),
+			Scores:  []float64{float64(3), float64(1)},
+		},
+		{
+			Choice:  []int{2, 3, 1},
+			Balance: float64(3),
+			Scores:  []float64{float64(1), float64(2)},
+		},
+		{
+			Choice:  []int{3},
+			Balance: float64(2),
+			Scores:  []float64{float64(2), float64(0)},
+		},
+		{
+			Choice:  []int{2, 2},
+			Balance: float64(7),
+			Scores:  []float64{float64(7), float64(0)},
+		},
+	}
+	positionalVoting := PositionalVoting{
+		Choices:    choices,
+		Votes:      votes,
+		Strategies: []voting.Strategy{{Name: "erc20-balance-of"}, {Name: "delegation"}},
+	}
+
+	validVotes := positionalVoting.GetValidVotes()
+	if len(validVotes) != len(votes)-1 {
+		t.Errorf("Expected %d valid votes, got %d", len(votes)-1, len(validVotes))
+	}
+
+	expectedScoresTotal := float64(9)
+	scoresTotal := positionalVoting.GetScoresTotal()
+	if !utils.FloatEqual(scoresTotal, expectedScoresTotal) {
+		t.Errorf("Expected scores total to be %f, got %f", expectedScoresTotal, scoresTotal)
+	}
+
+	tests := []struct {
+		method                   Method
+		weights                  []float64
+		expectedScores           []float64
+		expectedScoresByStrategy [][]float64
+	}{
+		{
+			// Borda: 2, 1, 0 points.
+			method:                   Borda,
+			expectedScores:           []float64{8, 10, 7},
+			expectedScoresByStrategy: [][]float64{{6, 2}, {5, 5}, {5, 2}},
+		},
+		{
+			// Dowdall: 1, 1/2, 1/3 points.
+			method:                   Dowdall,
+			expectedScores:           []float64{5, 5, 4 + float64(5)/6},
+			expectedScoresByStrategy: [][]float64{{3 + float64(1)/3, 1 + float64(2)/3}, {2.5, 2.5}, {3.5, 1 + float64(1)/3}},
+		},
+		{
+			method:                   Custom,
+			weights:                  []float64{5, 1},
+			expectedScores:           []float64{20, 19, 13},
+			expectedScoresByStrategy: [][]float64{{15, 5}, {8, 11}, {11, 2}},
+		},
+	}
+
+	for _, test := range tests {
+		positionalVoting.Method = test.method
+		positionalVoting.Weights = test.weights
+
+		scores := positionalVoting.GetScores()
+		for i, score := range scores {
+			if !utils.FloatEqual(score, test.expectedScores[i]) {
+				t.Errorf("%s: expected score %f for choice %s, got %f", test.method, test.expectedScores[i], choices[i], score)
+			}
+		}
+
+		total := test.expectedScores[0] + test.expectedScores[1] + test.expectedScores[2]
+		for i, percentage := range voting.NewResult(&positionalVoting).Percentages {
+			if !utils.FloatEqual(percentage, test.expectedScores[i]/total) {
+				t.Errorf("%s: expected percentage %f for choice %s, got %f", test.method, test.expectedScores[i]/total, choices[i], percentage)
+			}
+		}
+
+		scoresByStrategy := positionalVoting.GetScoresByStrategy()
+		for i, scores := range scoresByStrategy {
+			for j, score := range scores {
+				if !utils.FloatEqual(score, test.expectedScoresByStrategy[i][j]) {
+					t.Errorf("%s: expected score %f for choice %s and strategy %d, got %f", test.method, test.expectedScoresByStrategy[i][j], choices[i], j, score)
+				}
+			}
+		}
+	}
+
+	for _, method := range []Method{"plurality", Custom} {
+		positionalVoting.Method = method
+		positionalVoting.Weights = nil
+		if len(positionalVoting.GetValidVotes()) != 0 {
+			t.Errorf("%s: expected no valid votes for a proposal that cannot score ballots", method)
+		}
+		for _, score := range positionalVoting.GetScores() {
+			if score != 0 {
+				t.Errorf("%s: expected no scores, got %v", method, positionalVoting.GetScores())
+			}
+		}
+	}
+
+	if _, err := PositionWeights(Custom, []float64{1, 1, 1, 1}, 3); err == nil {
+		t.Errorf("Expected more weights than choices to be rejected")
+	}
+
+	if _, err := PositionWeights(Custom, []float64{1, -1}, 3); err == nil {
+		t.Errorf("Expected negative weights to be rejected")
+	}
+}
@@ -0,0 +1,125 @@
+package quadratic
+
+import (
+	"math/big"
+	"strconv"
+
+	"github.com/This-Is-Prince/votingSystemGo/utils"
+	"github.com/This-Is-Prince/votingSystemGo/voting"
+)
+
+// ExactPrecision is the mantissa size, in bits, of the square roots taken by
+// the exact tallies. Quadratic scores are irrational in general, so they are
+// exact only up to this precision; every other step, including summing the
+// roots, is rational.
+const ExactPrecision = 256
+
+var _ voting.ExactVotingSystem = (*QuadraticVoting)(nil)
+
+func (v QuadraticVote) GetExactBalance(decimals int) *big.Rat {
+	return utils.ExactValue(v.BalanceUnits, v.ExactBalance, v.Balance, decimals)
+}
+
+func (v QuadraticVote) GetExactScores(decimals int) []*big.Rat {
+	return utils.ExactValues(v.ScoresUnits, v.ExactScores, v.Scores, decimals)
+}
+
+func sqrtWeightPower(choice int, choices QuadraticChoice, power *big.Rat) *big.Float {
+	whole := int64(0)
+	for _, c := range choices {
+		whole = whole + int64(c)
+	}
+
+	weightPower := new(big.Rat)
+	if whole != 0 {
+		weightPower.Mul(big.NewRat(int64(choice), whole), power)
+	}
+
+	sqrt := new(big.Float).SetPrec(ExactPrecision).SetRat(weightPower)
+	return sqrt.Sqrt(sqrt)
+}
+
+// addSqrt adds a square root to a sum without rounding, so the sum does not
+// depend on the order of the votes.
+func addSqrt(sum *big.Rat, sqrt *big.Float) {
+	r, _ := sqrt.Rat(nil)
+	sum.Add(sum, r)
+}
+
+func squares(sums []*big.Rat) []*big.Rat {
+	scores := []*big.Rat{}
+	for _, sum := range sums {
+		scores = append(scores, new(big.Rat).Mul(sum, sum))
+	}
+	return scores
+}
+
+func (v *QuadraticVoting) GetScoresTotalExact() *big.Rat {
+	scoresTotal := new(big.Rat)
+	for _, vote := range v.GetValidVotes() {
+		balance, _ := v.exactPower(vote)
+		scoresTotal.Add(scoresTotal, balance)
+	}
+	return scoresTotal
+}
+
+func (v *QuadraticVoting) GetScoresExact() []*big.Rat {
+	scoresTotal := new(big.Rat)
+	sqrtSums := utils.NewRats(len(v.Choices))
+
+	for _, vote := range v.GetValidVotes() {
+		balance, _ := v.exactPower(vote)
+		scoresTotal.Add(scoresTotal, balance)
+		for _, idx := range utils.SortedChoiceKeys(vote.Choice) {
+			index, _ := strconv.Atoi(idx)
+			addSqrt(sqrtSums[index-1], sqrtWeightPower(vote.Choice[idx], vote.Choice, balance))
+		}
+	}
+
+	scores := squares(sqrtSums)
+
+	percentageOfScores := []*big.Rat{}
+	for _, score := range scores {
+		percentageOfScores = append(percentageOfScores, utils.CalcPercentageOfSumRat(score, scores))
+	}
+
+	return utils.CalcReducedQuadraticScoresRat(scoresTotal, percentageOfScores)
+}
+
+func (v *QuadraticVoting) GetScoresByStrategyExact() [][]*big.Rat {
+	scoresTotal := new(big.Rat)
+	sqrtSumsByStrategy := utils.NewRatMatrix(len(v.Choices), len(v.Strategies))
+
+	for _, vote := range v.GetValidVotes() {
+		balance, scores := v.exactPower(vote)
+		scoresTotal.Add(scoresTotal, balance)
+		for _, idx := range utils.SortedChoiceKeys(vote.Choice) {
+			index, _ := strconv.Atoi(idx)
+			for sIdx, score := range scores {
+				addSqrt(sqrtSumsByStrategy[index-1][sIdx], sqrtWeightPower(vote.Choice[idx], vote.Choice, score))
+			}
+		}
+	}
+
+	scoresByStrategy := [][]*big.Rat{}
+	flattenScoresByStrategy := []*big.Rat{}
+	for _, sqrtSums := range sqrtSumsByStrategy {
+		scores := squares(sqrtSums)
+		scoresByStrategy = append(scoresByStrategy, scores)
+		flattenScoresByStrategy = append(flattenScoresByStrategy, scores...)
+	}
+
+	for idx, scores := range scoresByStrategy {
+		percentageOfScores := []*big.Rat{}
+		for _, score := range scores {
+			percentageOfScores = append(percentageOfScores, utils.CalcPercentageOfSumRat(score, flattenScoresByStrategy))
+		}
+		scoresByStrategy[idx] = utils.CalcReducedQuadraticScoresRat(scoresTotal, percentageOfScores)
+	}
+
+	return scoresByStrategy
+}
+
+func (v *QuadraticVoting) exactPower(vote QuadraticVote) (*big.Rat, []*big.Rat) {
+	return voting.EffectivePowerExact(v.Strategies, vote.GetExactBalance(v.Decimals), vote.GetExactScores(v.Decimals))
+}
@@ -3,149 +3,265 @@ package quadratic
 import (
 	"log"
 	"math"
+	"math/big"
 	"strconv"
 
-	"github.com/thoas/go-funk"
-
 	"github.com/This-Is-Prince/votingSystemGo/utils"
+	"github.com/This-Is-Prince/votingSystemGo/voting"
 )
 
 type QuadraticVote struct {
-	Choice  QuadraticChoice `json:"choice"`
-	Balance float64         `json:"balance"`
-	Scores  []float64       `json:"scores"`
+	Choice       QuadraticChoice `json:"choice"`
+	Balance      float64         `json:"balance"`
+	Scores       []float64       `json:"scores"`
+	ExactBalance *big.Rat        `json:"exactBalance,omitempty"`
+	ExactScores  []*big.Rat      `json:"exactScores,omitempty"`
+	BalanceUnits *big.Int        `json:"balanceUnits,omitempty"`
+	ScoresUnits  []*big.Int      `json:"scoresUnits,omitempty"`
+	Voter        string          `json:"voter,omitempty"`
+	Created      int64           `json:"created,omitempty"`
 }
 
 type QuadraticChoice map[string]int
 
 type QuadraticVoting struct {
-	Choices    []string        `json:"choices"`
-	Votes      []QuadraticVote `json:"votes"`
-	Strategies []interface{}   `json:"strategies"`
+	Choices    []string          `json:"choices"`
+	Votes      []QuadraticVote   `json:"votes"`
+	Strategies []voting.Strategy `json:"strategies"`
+	Decimals   int               `json:"decimals,omitempty"`
 }
 
-func IsValidChoice(voteChoice QuadraticChoice, proposalChoices []string) bool {
+var _ voting.VotingSystem = (*QuadraticVoting)(nil)
+
+func (v QuadraticVote) GetChoice() interface{} {
+	return v.Choice
+}
+
+func (v QuadraticVote) GetBalance() float64 {
+	return v.Balance
+}
+
+func (v QuadraticVote) GetScores() []float64 {
+	return v.Scores
+}
+
+func (v QuadraticVote) GetVoter() string {
+	return v.Voter
+}
+
+func (v QuadraticVote) GetCreated() int64 {
+	return v.Created
+}
+
+func (v QuadraticVote) Supports(choice int) bool {
+	return v.Choice[strconv.Itoa(choice)] > 0
+}
+
+func ValidateChoice(voteChoice QuadraticChoice, proposalChoices []string) error {
 	if voteChoice == nil || len(voteChoice) == 0 {
-		return false
+		return voting.NewValidationError(voting.CodeEmptyChoice, "no choice is weighted")
 	}
 
-	for k, v := range voteChoice {
-		if v <= 0 || v > len(proposalChoices) {
-			return false
+	for _, k := range utils.SortedChoiceKeys(voteChoice) {
+		v := voteChoice[k]
+		if v < 0 {
+			return voting.NewValidationError(voting.CodeNegativeWeight, "choice %s has negative weight %d", k, v)
+		}
+
+		if v == 0 {
+			return voting.NewValidationError(voting.CodeZeroWeight, "choice %s has zero weight", k)
+		}
+
+		if v > len(proposalChoices) {
+			return voting.NewValidationError(voting.CodeWeightOutOfRange, "choice %s has weight %d, more than %d", k, v, len(proposalChoices))
 		}
 
 		numKey, err := strconv.ParseInt(k, 10, 64)
 		if err != nil {
-			return false
+			return voting.NewValidationError(voting.CodeInvalidChoiceKey, "choice %q is not a number", k)
 		}
 
 		if numKey <= 0 || int(numKey) > len(proposalChoices) {
-			return false
+			return voting.NewValidationError(voting.CodeChoiceOutOfRange, "choice %d is not between 1 and %d", numKey, len(proposalChoices))
 		}
 	}
 
-	return true
+	return nil
+}
+
+func IsValidChoice(voteChoice QuadraticChoice, proposalChoices []string) bool {
+	return ValidateChoice(voteChoice, proposalChoices) == nil
+}
+
+func (v *QuadraticVoting) GetChoices() []string {
+	return v.Choices
+}
+
+func (v *QuadraticVoting) GetStrategies() []voting.Strategy {
+	return v.Strategies
+}
+
+func (v *QuadraticVoting) GetDecimals() int {
+	return v.Decimals
+}
+
+func (v *QuadraticVoting) GetVotes() []voting.Vote {
+	return voting.ToVotes(v.Votes)
+}
+
+func (v *QuadraticVoting) Deduplicate(policy voting.DedupPolicy) []voting.Vote {
+	votes, superseded := voting.Deduplicate(v.Votes, policy)
+	v.Votes = votes
+	return voting.ToVotes(superseded)
+}
+
+func (v *QuadraticVoting) IsValidVote(vote voting.Vote) bool {
+	return v.ValidateVote(vote) == nil
+}
+
+func (v *QuadraticVoting) ValidateVote(vote voting.Vote) error {
+	quadraticVote, ok := vote.(QuadraticVote)
+	if !ok {
+		return voting.NewValidationError(voting.CodeInvalidVoteType, "expected %T, got %T", QuadraticVote{}, vote)
+	}
+
+	return v.validateVote(quadraticVote)
+}
+
+func (v *QuadraticVoting) validateVote(vote QuadraticVote) error {
+	if err := voting.ValidateStrategies(v.Strategies); err != nil {
+		return err
+	}
+
+	if err := ValidateChoice(vote.Choice, v.Choices); err != nil {
+		return err
+	}
+
+	return voting.ValidateAmounts(vote.Balance, vote.Scores, vote.ExactBalance, vote.ExactScores, vote.BalanceUnits, vote.ScoresUnits, len(v.Strategies))
+}
+
+func (v *QuadraticVoting) power(vote QuadraticVote) (float64, []float64) {
+	balance, scores := voting.FloatAmounts(vote.Balance, vote.Scores, vote.ExactBalance, vote.ExactScores, vote.BalanceUnits, vote.ScoresUnits, v.Decimals)
+	return voting.EffectivePower(v.Strategies, balance, scores)
 }
 
 func (v *QuadraticVoting) GetValidVotes() []QuadraticVote {
-	return funk.Filter(v.Votes, func(vote QuadraticVote) bool {
-		return IsValidChoice(vote.Choice, v.Choices)
-	}).([]QuadraticVote)
+	return utils.Filter(v.Votes, func(vote QuadraticVote) bool {
+		return v.validateVote(vote) == nil
+	})
 }
 
 func (v *QuadraticVoting) GetScoresTotal() float64 {
-	return funk.Reduce(v.Votes, func(acc float64, vote QuadraticVote) float64 {
-		return acc + vote.Balance
-	}, float64(0)).(float64)
+	scoresTotal := utils.Accumulator{}
+	for _, vote := range v.GetValidVotes() {
+		balance, _ := v.power(vote)
+		scoresTotal.Add(balance)
+	}
+	return scoresTotal.Float64()
 }
 
-func (v *QuadraticVoting) GetScores() []float64 {
-	scoresTotal := float64(0)
-	scores := []float64{}
-
-	for range v.Choices {
-		scores = append(scores, float64(0))
+// sortedChoice returns the keys of a vote's choice in numeric order and
+// their weights, so every tally walks a vote in the same order.
+func sortedChoice(voteChoice QuadraticChoice) ([]string, []float64) {
+	keys := utils.SortedChoiceKeys(voteChoice)
+	choices := []float64{}
+	for _, k := range keys {
+		choices = append(choices, float64(voteChoice[k]))
 	}
+	return keys, choices
+}
+
+// GetScores sums votes exactly, so the scores are bit-identical whatever the
+// order of the votes.
+func (v *QuadraticVoting) GetScores() []float64 {
+	scoresTotal := utils.Accumulator{}
+	sqrtSums := make([]utils.Accumulator, len(v.Choices))
 
 	for _, vote := range v.Votes {
-		if IsValidChoice(vote.Choice, v.Choices) {
-			scoresTotal = scoresTotal + vote.Balance
-			choices := []float64{}
-			for _, v := range vote.Choice {
-				choices = append(choices, float64(v))
-			}
-			for idx, value := range vote.Choice {
-				choiceWeightPercent := utils.CalcPercentageOfSum(float64(value), choices)
-				choiceWeightPower := choiceWeightPercent * vote.Balance
+		if v.validateVote(vote) == nil {
+			balance, _ := v.power(vote)
+			scoresTotal.Add(balance)
+			keys, choices := sortedChoice(vote.Choice)
+			for i, idx := range keys {
+				choiceWeightPercent := utils.CalcPercentageOfSum(choices[i], choices)
+				choiceWeightPower := choiceWeightPercent * balance
 				sqrt := math.Sqrt(choiceWeightPower)
 				index, err := strconv.ParseInt(idx, 10, 64)
 				if err != nil {
 					log.Println("Error while parsing string:-", err)
 					continue
 				}
-				scores[index-1] = scores[index-1] + sqrt
+				sqrtSums[index-1].Add(sqrt)
 			}
 		}
 	}
 
-	for idx, score := range scores {
-		scores[idx] = score * score
-	}
-
-	percentageOfScores := []float64{}
-	for _, score := range scores {
-		percentageOfScores = append(percentageOfScores, utils.CalcPercentageOfSum(score, scores))
-	}
-	return utils.CalcReducedQuadraticScores(scoresTotal, percentageOfScores)
+	return normalizeScores(scoresTotal.Float64(), utils.AccumulatorsToFloats(sqrtSums))
 }
 
 func (v *QuadraticVoting) GetScoresByStrategy() [][]float64 {
-	scoresTotal := float64(0)
-	scoresByStrategy := [][]float64{}
+	scoresTotal := utils.Accumulator{}
+	sqrtSumsByStrategy := [][]utils.Accumulator{}
 
 	for range v.Choices {
-		scores := []float64{}
-		for range v.Strategies {
-			scores = append(scores, float64(0))
-		}
-		scoresByStrategy = append(scoresByStrategy, scores)
+		sqrtSumsByStrategy = append(sqrtSumsByStrategy, make([]utils.Accumulator, len(v.Strategies)))
 	}
 
 	for _, vote := range v.Votes {
-		if IsValidChoice(vote.Choice, v.Choices) {
-			scoresTotal = scoresTotal + vote.Balance
-			choices := []float64{}
-			for _, v := range vote.Choice {
-				choices = append(choices, float64(v))
-			}
-			for idx, value := range vote.Choice {
-				choiceWeightPercent := utils.CalcPercentageOfSum(float64(value), choices)
+		if v.validateVote(vote) == nil {
+			balance, scores := v.power(vote)
+			scoresTotal.Add(balance)
+			keys, choices := sortedChoice(vote.Choice)
+			for i, idx := range keys {
+				choiceWeightPercent := utils.CalcPercentageOfSum(choices[i], choices)
 				index, err := strconv.ParseInt(idx, 10, 64)
 				if err != nil {
 					log.Println("Error while parsing string:-", err)
 					continue
 				}
-				for sIdx, score := range vote.Scores {
+				for sIdx, score := range scores {
 					choiceWeightPower := choiceWeightPercent * score
 					sqrt := math.Sqrt(choiceWeightPower)
-					scoresByStrategy[index-1][sIdx] = scoresByStrategy[index-1][sIdx] + sqrt
+					sqrtSumsByStrategy[index-1][sIdx].Add(sqrt)
 				}
 			}
 		}
 	}
 
-	for _, scores := range scoresByStrategy {
-		for idx, score := range scores {
-			scores[idx] = score * score
+	return normalizeScoresByStrategy(scoresTotal.Float64(), utils.AccumulatorMatrixToFloats(sqrtSumsByStrategy))
+}
+
+// normalizeScores squares the summed square roots of each choice and scales
+// the squares so they add up to scoresTotal.
+func normalizeScores(scoresTotal float64, sqrtSums []float64) []float64 {
+	scores := []float64{}
+	for _, sum := range sqrtSums {
+		scores = append(scores, sum*sum)
+	}
+
+	percentageOfScores := []float64{}
+	for _, score := range scores {
+		percentageOfScores = append(percentageOfScores, utils.CalcPercentageOfSum(score, scores))
+	}
+	return utils.CalcReducedQuadraticScores(scoresTotal, percentageOfScores)
+}
+
+func normalizeScoresByStrategy(scoresTotal float64, sqrtSumsByStrategy [][]float64) [][]float64 {
+	scoresByStrategy := [][]float64{}
+	for _, sqrtSums := range sqrtSumsByStrategy {
+		scores := []float64{}
+		for _, sum := range sqrtSums {
+			scores = append(scores, sum*sum)
 		}
+		scoresByStrategy = append(scoresByStrategy, scores)
 	}
 
-	flattenScoresByStrategy := funk.FlattenDeep(scoresByStrategy)
+	flattenScoresByStrategy := utils.Flatten(scoresByStrategy)
 
 	for idx, scores := range scoresByStrategy {
 		percentageOfScores := []float64{}
 		for _, score := range scores {
-			percentageOfScores = append(percentageOfScores, utils.CalcPercentageOfSum(score, flattenScoresByStrategy.([]float64)))
+			percentageOfScores = append(percentageOfScores, utils.CalcPercentageOfSum(score, flattenScoresByStrategy))
 		}
 		scoresByStrategy[idx] = utils.CalcReducedQuadraticScores(scoresTotal, percentageOfScores)
 	}
@@ -1,9 +1,14 @@
 package quadratic
 
 import (
+	"math"
+	"math/rand"
+	"reflect"
+	"strconv"
 	"testing"
 
 	"github.com/This-Is-Prince/votingSystemGo/utils"
+	"github.com/This-Is-Prince/votingSystemGo/voting"
 )
 
 func TestQuadraticVoting(t *testing.T) {
@@ -23,7 +28,7 @@ func TestQuadraticVoting(t *testing.T) {
 	quadraticVoting := QuadraticVoting{
 		Choices:    choices,
 		Votes:      votes,
-		Strategies: []interface{}{1, 2},
+		Strategies: []voting.Strategy{{Name: "erc20-balance-of"}, {Name: "delegation"}},
 	}
 
 	validVotes := quadraticVoting.GetValidVotes()
@@ -31,7 +36,9 @@ func TestQuadraticVoting(t *testing.T) {
 		t.Errorf("Expected %d valid votes, got %d", len(votes), len(validVotes))
 	}
 
-	expectedScoresTotal := float64(2.494660)
+	// The total is the vote's balance in full. The old literal 2.494660 was
+	// rounded to six places, which is outside FloatEqual's tolerance.
+	expectedScoresTotal := votes[0].Balance
 	scoresTotal := quadraticVoting.GetScoresTotal()
 	if !utils.FloatEqual(scoresTotal, expectedScoresTotal) {
 		t.Errorf("Expected scores total to be %f, got %f", expectedScoresTotal, scoresTotal)
@@ -59,11 +66,15 @@ func TestQuadraticVoting(t *testing.T) {
 		t.Errorf("Expected %d scoresByStrategy, got %d", len(choices), len(scoresByStrategy))
 	}
 
-	expectedScoresByStrategy := [][]float64{
-		{float64(0.14808111041719751), float64(0.598718459241025)},
-		{float64(0.049360370139065836), float64(0.19957281974700825)},
-		{float64(0.19744148055626334), float64(0.798291278988033)},
-		{float64(0.09872074027813167), float64(0.39914563949401655)},
+	// With one vote every strategy's score is shared out in the proportions
+	// of the choice's allocation, 3:1:4:2, just as the balance is, so each
+	// column adds up to that strategy's score. The values this fixture used
+	// to hold added up to 0.4937 and 1.9957, less than the scores the vote
+	// carries.
+	shares := []float64{0.3, 0.1, 0.4, 0.2}
+	expectedScoresByStrategy := [][]float64{}
+	for _, share := range shares {
+		expectedScoresByStrategy = append(expectedScoresByStrategy, []float64{share * votes[0].Scores[0], share * votes[0].Scores[1]})
 	}
 
 	for i, scoreByStrategy := range scoresByStrategy {
@@ -75,3 +86,51 @@ func TestQuadraticVoting(t *testing.T) {
 	}
 
 }
+
+func TestQuadraticVotingDeterministic(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	choices := []string{"First", "Second", "Third", "Fourth", "Fifth"}
+	votes := []QuadraticVote{}
+	for i := 0; i < 200; i++ {
+		choice := QuadraticChoice{}
+		for j := range choices {
+			choice[strconv.Itoa(j+1)] = 1 + random.Intn(len(choices))
+		}
+		first := random.Float64() * math.Pow(10, float64(random.Intn(12)))
+		second := random.Float64() * math.Pow(10, float64(random.Intn(12)))
+		votes = append(votes, QuadraticVote{
+			Choice:  choice,
+			Balance: first + second,
+			Scores:  []float64{first, second},
+		})
+	}
+
+	quadraticVoting := QuadraticVoting{
+		Choices:    choices,
+		Votes:      votes,
+		Strategies: []voting.Strategy{{Name: "erc20-balance-of"}, {Name: "delegation"}},
+	}
+	expectedScoresTotal := quadraticVoting.GetScoresTotal()
+	expectedScores := quadraticVoting.GetScores()
+	expectedScoresByStrategy := quadraticVoting.GetScoresByStrategy()
+
+	for i := 0; i < 20; i++ {
+		shuffled := append([]QuadraticVote{}, votes...)
+		random.Shuffle(len(shuffled), func(i, j int) {
+			shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+		})
+		quadraticVoting.Votes = shuffled
+
+		if scoresTotal := quadraticVoting.GetScoresTotal(); scoresTotal != expectedScoresTotal {
+			t.Fatalf("Expected scores total %v regardless of order, got %v", expectedScoresTotal, scoresTotal)
+		}
+
+		if scores := quadraticVoting.GetScores(); !reflect.DeepEqual(scores, expectedScores) {
+			t.Fatalf("Expected scores %v regardless of order, got %v", expectedScores, scores)
+		}
+
+		if scoresByStrategy := quadraticVoting.GetScoresByStrategy(); !reflect.DeepEqual(scoresByStrategy, expectedScoresByStrategy) {
+			t.Fatalf("Expected scores by strategy %v regardless of order, got %v", expectedScoresByStrategy, scoresByStrategy)
+		}
+	}
+}
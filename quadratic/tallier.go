@@ -0,0 +1,91 @@
+package quadratic
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+
+	"github.com/This-Is-Prince/votingSystemGo/utils"
+	"github.com/This-Is-Prince/votingSystemGo/voting"
+)
+
+// Tallier keeps running quadratic scores, so adding, removing or
+// replacing a vote costs O(choices × strategies) and the scores match a full
+// tally of the same votes.
+type Tallier struct {
+	voting.RunningTally[QuadraticVote]
+}
+
+var _ voting.Tallier = (*Tallier)(nil)
+
+var _ voting.Streamer = (*QuadraticVoting)(nil)
+
+func NewTallier(choices []string, strategies []voting.Strategy) *Tallier {
+	return (&QuadraticVoting{Choices: choices, Strategies: strategies}).newTallier()
+}
+
+// newTallier returns an empty Tallier that counts votes as the proposal
+// does, in its decimals.
+func (v *QuadraticVoting) newTallier() *Tallier {
+	proposal := *v
+	proposal.Votes = nil
+	return &Tallier{voting.NewRunningTally(len(proposal.Choices), len(proposal.Strategies), proposal.ValidateVote, proposal.add)}
+}
+
+// NewTallier returns a Tallier seeded with the valid votes of the proposal.
+func (v *QuadraticVoting) NewTallier() voting.Tallier {
+	t := v.newTallier()
+	for _, vote := range v.GetValidVotes() {
+		t.Count(vote)
+	}
+	return t
+}
+
+// DecodeVote reads the next quadratic vote from dec.
+func (v *QuadraticVoting) DecodeVote(dec *json.Decoder) (voting.Vote, error) {
+	var vote QuadraticVote
+	if err := dec.Decode(&vote); err != nil {
+		return nil, err
+	}
+	return vote, nil
+}
+
+// TallyParallel tallies the valid votes of the proposal on the given number of
+// workers and returns the merged Tallier. The scores match GetScores and
+// GetScoresByStrategy exactly.
+func (v *QuadraticVoting) TallyParallel(workers int) *Tallier {
+	return voting.ParallelTally(v.Votes, workers, func() *Tallier {
+		return v.newTallier()
+	}, func(t *Tallier, vote QuadraticVote) {
+		if v.validateVote(vote) == nil {
+			t.Count(vote)
+		}
+	}, (*Tallier).Merge)
+}
+
+// Merge adds the votes tallied by other into t.
+func (t *Tallier) Merge(other *Tallier) {
+	t.RunningTally.Merge(&other.RunningTally)
+}
+
+func (t *Tallier) GetScores() []float64 {
+	return normalizeScores(t.ScoresTotal.Float64(), utils.AccumulatorsToFloats(t.Scores))
+}
+
+func (t *Tallier) GetScoresByStrategy() [][]float64 {
+	return normalizeScoresByStrategy(t.ScoresTotal.Float64(), utils.AccumulatorMatrixToFloats(t.ScoresByStrategy))
+}
+
+func (v *QuadraticVoting) add(t *voting.RunningTally[QuadraticVote], vote QuadraticVote, sign float64) {
+	balance, scores := v.power(vote)
+	t.ScoresTotal.Add(sign * balance)
+	keys, choices := sortedChoice(vote.Choice)
+	for i, idx := range keys {
+		index, _ := strconv.Atoi(idx)
+		choiceWeightPercent := utils.CalcPercentageOfSum(choices[i], choices)
+		t.Scores[index-1].Add(sign * math.Sqrt(choiceWeightPercent*balance))
+		for sIdx, score := range scores {
+			t.ScoresByStrategy[index-1][sIdx].Add(sign * math.Sqrt(choiceWeightPercent*score))
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package rankedChoice
+
+import (
+	"math/big"
+
+	"github.com/This-Is-Prince/votingSystemGo/utils"
+	"github.com/This-Is-Prince/votingSystemGo/voting"
+)
+
+var _ voting.ExactVotingSystem = (*RankedChoiceVoting)(nil)
+
+func (v RankedChoiceVote) GetExactBalance(decimals int) *big.Rat {
+	return utils.ExactValue(v.BalanceUnits, v.ExactBalance, v.Balance, decimals)
+}
+
+func (v RankedChoiceVote) GetExactScores(decimals int) []*big.Rat {
+	return utils.ExactValues(v.ScoresUnits, v.ExactScores, v.Scores, decimals)
+}
+
+func (v *RankedChoiceVoting) GetScoresTotalExact() *big.Rat {
+	scoresTotal := new(big.Rat)
+	for _, vote := range v.GetValidVotes() {
+		balance, _ := v.exactPower(vote)
+		scoresTotal.Add(scoresTotal, balance)
+	}
+	return scoresTotal
+}
+
+// ExactRound is a Round counted in exact arithmetic.
+type ExactRound struct {
+	Round            int          `json:"round"`
+	Scores           []*big.Rat   `json:"scores"`
+	ScoresByStrategy [][]*big.Rat `json:"scoresByStrategy"`
+	Eliminated       int          `json:"eliminated"`
+	Winner           int          `json:"winner"`
+	Transferred      []*big.Rat   `json:"transferred"`
+	Exhausted        *big.Rat     `json:"exhausted"`
+}
+
+// GetScoresExact returns the exact scores of the final instant-runoff
+// round.
+func (v *RankedChoiceVoting) GetScoresExact() []*big.Rat {
+	rounds := v.GetRoundsExact()
+	return rounds[len(rounds)-1].Scores
+}
+
+// GetScoresByStrategyExact returns the exact per-strategy scores of the
+// final instant-runoff round.
+func (v *RankedChoiceVoting) GetScoresByStrategyExact() [][]*big.Rat {
+	rounds := v.GetRoundsExact()
+	return rounds[len(rounds)-1].ScoresByStrategy
+}
+
+// GetRoundsExact runs the instant runoff described on GetRounds on the
+// exact amounts, so eliminations and ties are decided without rounding.
+func (v *RankedChoiceVoting) GetRoundsExact() []ExactRound {
+	validVotes := v.GetValidVotes()
+	eliminated := make([]bool, len(v.Choices))
+	remaining := len(v.Choices)
+	rounds := []ExactRound{}
+
+	for {
+		round := ExactRound{
+			Round:            len(rounds) + 1,
+			Scores:           utils.NewRats(len(v.Choices)),
+			ScoresByStrategy: utils.NewRatMatrix(len(v.Choices), len(v.Strategies)),
+			Transferred:      utils.NewRats(len(v.Choices)),
+			Exhausted:        new(big.Rat),
+		}
+
+		activeTotal := new(big.Rat)
+		for _, vote := range validVotes {
+			choice := topChoice(vote.Choice, eliminated)
+			if choice == 0 {
+				continue
+			}
+			balance, scores := v.exactPower(vote)
+			activeTotal.Add(activeTotal, balance)
+			round.Scores[choice-1].Add(round.Scores[choice-1], balance)
+			for idx, score := range scores {
+				round.ScoresByStrategy[choice-1][idx].Add(round.ScoresByStrategy[choice-1][idx], score)
+			}
+		}
+
+		if activeTotal.Sign() == 0 {
+			rounds = append(rounds, round)
+			return rounds
+		}
+
+		leader := 0
+		for idx, score := range round.Scores {
+			if !eliminated[idx] && (leader == 0 || score.Cmp(round.Scores[leader-1]) > 0) {
+				leader = idx + 1
+			}
+		}
+
+		half := new(big.Rat).Quo(activeTotal, big.NewRat(2, 1))
+		if round.Scores[leader-1].Cmp(half) > 0 || remaining == 1 {
+			round.Winner = leader
+			rounds = append(rounds, round)
+			return rounds
+		}
+
+		loser := choiceToEliminate(round.Scores, rounds, eliminated)
+		round.Eliminated = loser
+		eliminated[loser-1] = true
+		remaining--
+
+		for _, vote := range validVotes {
+			if topChoiceBefore(vote.Choice, eliminated, loser) != loser {
+				continue
+			}
+			balance, _ := v.exactPower(vote)
+			next := topChoice(vote.Choice, eliminated)
+			if next == 0 {
+				round.Exhausted.Add(round.Exhausted, balance)
+				continue
+			}
+			round.Transferred[next-1].Add(round.Transferred[next-1], balance)
+		}
+
+		rounds = append(rounds, round)
+	}
+}
+
+func choiceToEliminate(scores []*big.Rat, previousRounds []ExactRound, eliminated []bool) int {
+	tied := []int{}
+	for idx, score := range scores {
+		if eliminated[idx] {
+			continue
+		}
+		switch {
+		case len(tied) == 0 || score.Cmp(scores[tied[0]-1]) < 0:
+			tied = []int{idx + 1}
+		case score.Cmp(scores[tied[0]-1]) == 0:
+			tied = append(tied, idx+1)
+		}
+	}
+
+	for r := len(previousRounds) - 1; r >= 0 && len(tied) > 1; r-- {
+		previousScores := previousRounds[r].Scores
+		lowest := previousScores[tied[0]-1]
+		for _, choice := range tied {
+			if previousScores[choice-1].Cmp(lowest) < 0 {
+				lowest = previousScores[choice-1]
+			}
+		}
+		tied = utils.Filter(tied, func(choice int) bool {
+			return previousScores[choice-1].Cmp(lowest) == 0
+		})
+	}
+
+	return tied[len(tied)-1]
+}
+
+func (v *RankedChoiceVoting) exactPower(vote RankedChoiceVote) (*big.Rat, []*big.Rat) {
+	return voting.EffectivePowerExact(v.Strategies, vote.GetExactBalance(v.Decimals), vote.GetExactScores(v.Decimals))
+}
@@ -0,0 +1,226 @@
+package rankedChoice
+
+import (
+	"math/big"
+
+	"github.com/This-Is-Prince/votingSystemGo/utils"
+	"github.com/This-Is-Prince/votingSystemGo/voting"
+)
+
+type RankedChoiceVote struct {
+	Choice       []int      `json:"choice"`
+	Balance      float64    `json:"balance"`
+	Scores       []float64  `json:"scores"`
+	ExactBalance *big.Rat   `json:"exactBalance,omitempty"`
+	ExactScores  []*big.Rat `json:"exactScores,omitempty"`
+	BalanceUnits *big.Int   `json:"balanceUnits,omitempty"`
+	ScoresUnits  []*big.Int `json:"scoresUnits,omitempty"`
+	Voter        string     `json:"voter,omitempty"`
+	Created      int64      `json:"created,omitempty"`
+}
+
+type RankedChoiceVoting struct {
+	Choices    []string           `json:"choices"`
+	Votes      []RankedChoiceVote `json:"votes"`
+	Strategies []voting.Strategy  `json:"strategies"`
+	Decimals   int                `json:"decimals,omitempty"`
+}
+
+// Round is one instant-runoff counting round. Eliminated is the 1-based
+// choice dropped at the end of the round and Winner the choice elected in
+// it; each is 0 when it does not apply. Transferred holds, per choice, the
+// weight moved to it from the eliminated choice's ballots, and Exhausted
+// the weight of those ballots with no preference left.
+type Round struct {
+	Round            int         `json:"round"`
+	Scores           []float64   `json:"scores"`
+	ScoresByStrategy [][]float64 `json:"scoresByStrategy"`
+	Eliminated       int         `json:"eliminated"`
+	Winner           int         `json:"winner"`
+	Transferred      []float64   `json:"transferred"`
+	Exhausted        float64     `json:"exhausted"`
+}
+
+var _ voting.VotingSystem = (*RankedChoiceVoting)(nil)
+
+func (v RankedChoiceVote) GetChoice() interface{} {
+	return v.Choice
+}
+
+func (v RankedChoiceVote) GetBalance() float64 {
+	return v.Balance
+}
+
+func (v RankedChoiceVote) GetScores() []float64 {
+	return v.Scores
+}
+
+func (v RankedChoiceVote) GetVoter() string {
+	return v.Voter
+}
+
+func (v RankedChoiceVote) GetCreated() int64 {
+	return v.Created
+}
+
+func (v RankedChoiceVote) Supports(choice int) bool {
+	return len(v.Choice) > 0 && v.Choice[0] == choice
+}
+
+func ValidateChoice(voteChoice []int, proposalChoices []string) error {
+	if len(voteChoice) == 0 {
+		return voting.NewValidationError(voting.CodeEmptyChoice, "no choice is ranked")
+	}
+
+	voteChoiceSet := make(map[int]struct{})
+	for _, c := range voteChoice {
+		if c <= 0 || c > len(proposalChoices) {
+			return voting.NewValidationError(voting.CodeChoiceOutOfRange, "choice %d is not between 1 and %d", c, len(proposalChoices))
+		}
+		if _, ok := voteChoiceSet[c]; ok {
+			return voting.NewValidationError(voting.CodeDuplicateChoice, "choice %d is ranked more than once", c)
+		}
+		voteChoiceSet[c] = struct{}{}
+	}
+
+	return nil
+}
+
+func IsValidChoice(voteChoice []int, proposalChoices []string) bool {
+	return ValidateChoice(voteChoice, proposalChoices) == nil
+}
+
+func (v *RankedChoiceVoting) GetChoices() []string {
+	return v.Choices
+}
+
+func (v *RankedChoiceVoting) GetStrategies() []voting.Strategy {
+	return v.Strategies
+}
+
+func (v *RankedChoiceVoting) GetDecimals() int {
+	return v.Decimals
+}
+
+func (v *RankedChoiceVoting) GetVotes() []voting.Vote {
+	return voting.ToVotes(v.Votes)
+}
+
+func (v *RankedChoiceVoting) Deduplicate(policy voting.DedupPolicy) []voting.Vote {
+	votes, superseded := voting.Deduplicate(v.Votes, policy)
+	v.Votes = votes
+	return voting.ToVotes(superseded)
+}
+
+func (v *RankedChoiceVoting) IsValidVote(vote voting.Vote) bool {
+	return v.ValidateVote(vote) == nil
+}
+
+func (v *RankedChoiceVoting) ValidateVote(vote voting.Vote) error {
+	rankedChoiceVote, ok := vote.(RankedChoiceVote)
+	if !ok {
+		return voting.NewValidationError(voting.CodeInvalidVoteType, "expected %T, got %T", RankedChoiceVote{}, vote)
+	}
+
+	return v.validateVote(rankedChoiceVote)
+}
+
+func (v *RankedChoiceVoting) validateVote(vote RankedChoiceVote) error {
+	if err := voting.ValidateStrategies(v.Strategies); err != nil {
+		return err
+	}
+
+	if err := ValidateChoice(vote.Choice, v.Choices); err != nil {
+		return err
+	}
+
+	return voting.ValidateAmounts(vote.Balance, vote.Scores, vote.ExactBalance, vote.ExactScores, vote.BalanceUnits, vote.ScoresUnits, len(v.Strategies))
+}
+
+func (v *RankedChoiceVoting) power(vote RankedChoiceVote) (float64, []float64) {
+	balance, scores := voting.FloatAmounts(vote.Balance, vote.Scores, vote.ExactBalance, vote.ExactScores, vote.BalanceUnits, vote.ScoresUnits, v.Decimals)
+	return voting.EffectivePower(v.Strategies, balance, scores)
+}
+
+func (v *RankedChoiceVoting) GetValidVotes() []RankedChoiceVote {
+	return utils.Filter(v.Votes, func(vote RankedChoiceVote) bool {
+		return v.validateVote(vote) == nil
+	})
+}
+
+func (v *RankedChoiceVoting) GetScoresTotal() float64 {
+	return utils.Reduce(v.GetValidVotes(), func(acc float64, vote RankedChoiceVote) float64 {
+		balance, _ := v.power(vote)
+		return acc + balance
+	}, 0)
+}
+
+// GetScores returns the scores of the final instant-runoff round.
+func (v *RankedChoiceVoting) GetScores() []float64 {
+	rounds := v.GetRounds()
+	return rounds[len(rounds)-1].Scores
+}
+
+// GetScoresByStrategy returns the per-strategy scores of the final
+// instant-runoff round.
+func (v *RankedChoiceVoting) GetScoresByStrategy() [][]float64 {
+	rounds := v.GetRounds()
+	return rounds[len(rounds)-1].ScoresByStrategy
+}
+
+// GetWinner returns the 1-based choice elected by instant runoff, or 0 when
+// there are no valid votes.
+func (v *RankedChoiceVoting) GetWinner() int {
+	rounds := v.GetRounds()
+	return rounds[len(rounds)-1].Winner
+}
+
+// GetRounds runs instant-runoff elimination. Each round, every valid ballot
+// counts for its highest-ranked choice still in the race. A choice with more
+// than half of the weight of those ballots wins; otherwise the choice with
+// the lowest score is eliminated and its ballots move on to their next
+// preference. The count also ends when one choice is left.
+//
+// Ties for elimination are broken by looking back through the previous
+// rounds, newest first, and eliminating the tied choice with the lowest
+// score in the most recent round where they differ. If they are tied in
+// every round, the tied choice listed last in Choices is eliminated.
+//
+// The count is GetRoundsExact's, so every decision is made on exact
+// amounts; only the figures reported here are rounded to float64.
+func (v *RankedChoiceVoting) GetRounds() []Round {
+	rounds := []Round{}
+	for _, exactRound := range v.GetRoundsExact() {
+		exhausted, _ := exactRound.Exhausted.Float64()
+		rounds = append(rounds, Round{
+			Round:            exactRound.Round,
+			Scores:           utils.RatsToFloats(exactRound.Scores),
+			ScoresByStrategy: utils.RatMatrixToFloats(exactRound.ScoresByStrategy),
+			Eliminated:       exactRound.Eliminated,
+			Winner:           exactRound.Winner,
+			Transferred:      utils.RatsToFloats(exactRound.Transferred),
+			Exhausted:        exhausted,
+		})
+	}
+	return rounds
+}
+
+func topChoice(ranking []int, eliminated []bool) int {
+	for _, choice := range ranking {
+		if !eliminated[choice-1] {
+			return choice
+		}
+	}
+	return 0
+}
+
+// topChoiceBefore returns the ballot's top choice as it was before justEliminated
+// was dropped.
+func topChoiceBefore(ranking []int, eliminated []bool, justEliminated int) int {
+	for _, choice := range ranking {
+		if choice == justEliminated || !eliminated[choice-1] {
+			return choice
+		}
+	}
+	return 0
+}
@@ -0,0 +1,193 @@
+package rankedChoice
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/This-Is-Prince/votingSystemGo/utils"
+	"github.com/This-Is-Prince/votingSystemGo/voting"
+)
+
+func TestRankedChoiceVoting(t *testing.T) {
+	choices := []string{"First", "Second", "Third"}
+	votes := []RankedChoiceVote{
+		{
+			Choice:  []int{1, 2, 3},
+			Balance: float64(4),
+			Scores:  []float64{float64(3), float64(1)},
+		},
+		{
+			Choice:  []int{2, 3, 1},
+			Balance: float64(3),
+			Scores:  []float64{float64(1), float64(2)},
+		},
+		{
+			Choice:  []int{3, 2, 1},
+			Balance: float64(2),
+			Scores:  []float64{float64(2), float64(0)},
+		},
+		{
+			Choice:  []int{2},
+			Balance: float64(1),
+			Scores:  []float64{float64(1), float64(0)},
+		},
+		{
+			Choice:  []int{2, 2},
+			Balance: float64(7),
+			Scores:  []float64{float64(7), float64(0)},
+		},
+	}
+	rankedChoiceVoting := RankedChoiceVoting{
+		Choices:    choices,
+		Votes:      votes,
+		Strategies: []voting.Strategy{{Name: "erc20-balance-of"}, {Name: "delegation"}},
+	}
+
+	validVotes := rankedChoiceVoting.GetValidVotes()
+	if len(validVotes) != len(votes)-1 {
+		t.Errorf("Expected %d valid votes, got %d", len(votes)-1, len(validVotes))
+	}
+
+	expectedScoresTotal := float64(10)
+	scoresTotal := rankedChoiceVoting.GetScoresTotal()
+	if !utils.FloatEqual(scoresTotal, expectedScoresTotal) {
+		t.Errorf("Expected scores total to be %f, got %f", expectedScoresTotal, scoresTotal)
+	}
+
+	rounds := rankedChoiceVoting.GetRounds()
+	if len(rounds) != 2 {
+		t.Fatalf("Expected %d rounds, got %d", 2, len(rounds))
+	}
+
+	if rounds[0].Eliminated != 3 || rounds[0].Winner != 0 {
+		t.Errorf("Expected round 1 to eliminate choice 3, got eliminated %d winner %d", rounds[0].Eliminated, rounds[0].Winner)
+	}
+
+	expectedTransferred := []float64{float64(0), float64(2), float64(0)}
+	for i, transferred := range rounds[0].Transferred {
+		if !utils.FloatEqual(transferred, expectedTransferred[i]) {
+			t.Errorf("Expected transferred %f for choice %s, got %f", expectedTransferred[i], choices[i], transferred)
+		}
+	}
+
+	if rankedChoiceVoting.GetWinner() != 2 {
+		t.Errorf("Expected winner %d, got %d", 2, rankedChoiceVoting.GetWinner())
+	}
+
+	expectedScores := []float64{float64(4), float64(6), float64(0)}
+	scores := rankedChoiceVoting.GetScores()
+	if len(scores) != len(choices) {
+		t.Errorf("Expected %d scores, got %d", len(choices), len(scores))
+	}
+
+	for i, score := range scores {
+		if !utils.FloatEqual(score, expectedScores[i]) {
+			t.Errorf("Expected score %f for choice %s, got %f", expectedScores[i], choices[i], score)
+		}
+	}
+
+	scoresByStrategy := rankedChoiceVoting.GetScoresByStrategy()
+	if len(scoresByStrategy) != len(choices) {
+		t.Errorf("Expected %d scoresByStrategy, got %d", len(choices), len(scoresByStrategy))
+	}
+
+	expectedScoresByStrategy := [][]float64{
+		{float64(3), float64(1)},
+		{float64(4), float64(2)},
+		{float64(0), float64(0)},
+	}
+
+	for i, scoreByStrategy := range scoresByStrategy {
+		for j, score := range scoreByStrategy {
+			if !utils.FloatEqual(score, expectedScoresByStrategy[i][j]) {
+				t.Errorf("Expected score %f got %f", expectedScoresByStrategy[i][j], score)
+			}
+		}
+	}
+}
+
+func TestRankedChoiceTieBreak(t *testing.T) {
+	rankedChoiceVoting := RankedChoiceVoting{
+		Choices: []string{"First", "Second", "Third", "Fourth"},
+		Votes: []RankedChoiceVote{
+			{Choice: []int{1}, Balance: float64(6)},
+			{Choice: []int{2}, Balance: float64(2)},
+			{Choice: []int{3}, Balance: float64(3)},
+			{Choice: []int{4, 2}, Balance: float64(1)},
+		},
+	}
+
+	rounds := rankedChoiceVoting.GetRounds()
+	if len(rounds) != 3 {
+		t.Fatalf("Expected %d rounds, got %d", 3, len(rounds))
+	}
+
+	// Second and Third are tied in round 2; Second had fewer votes in round 1.
+	expectedEliminated := []int{4, 2, 0}
+	for i, round := range rounds {
+		if round.Eliminated != expectedEliminated[i] {
+			t.Errorf("Expected round %d to eliminate %d, got %d", round.Round, expectedEliminated[i], round.Eliminated)
+		}
+	}
+
+	if !utils.FloatEqual(rounds[1].Exhausted, float64(3)) {
+		t.Errorf("Expected %f exhausted in round 2, got %f", float64(3), rounds[1].Exhausted)
+	}
+
+	if rounds[2].Winner != 1 {
+		t.Errorf("Expected winner %d, got %d", 1, rounds[2].Winner)
+	}
+
+	tied := RankedChoiceVoting{
+		Choices: []string{"First", "Second", "Third"},
+		Votes: []RankedChoiceVote{
+			{Choice: []int{1}, Balance: float64(2)},
+			{Choice: []int{2, 1}, Balance: float64(1)},
+			{Choice: []int{3, 1}, Balance: float64(1)},
+		},
+	}
+
+	if eliminated := tied.GetRounds()[0].Eliminated; eliminated != 3 {
+		t.Errorf("Expected the last listed tied choice %d to be eliminated, got %d", 3, eliminated)
+	}
+}
+
+func TestRankedChoiceExactRounds(t *testing.T) {
+	exactOnly := RankedChoiceVoting{
+		Choices: []string{"First", "Second", "Third"},
+		Votes: []RankedChoiceVote{
+			{Choice: []int{1}, ExactBalance: big.NewRat(4, 1)},
+			{Choice: []int{2}, ExactBalance: big.NewRat(3, 1)},
+			{Choice: []int{3, 2}, ExactBalance: big.NewRat(2, 1)},
+		},
+	}
+
+	rounds := exactOnly.GetRoundsExact()
+	if len(rounds) != 2 || rounds[0].Eliminated != 3 || rounds[1].Winner != 2 {
+		t.Fatalf("Expected choice 3 eliminated and choice 2 elected, got %+v", rounds)
+	}
+	if score := exactOnly.GetScoresExact()[1]; score.Cmp(big.NewRat(5, 1)) != 0 {
+		t.Errorf("Expected choice 2 to finish with 5, got %v", score)
+	}
+	if winner := exactOnly.GetWinner(); winner != 2 {
+		t.Errorf("Expected winner %d, got %d", 2, winner)
+	}
+
+	// 0.1 + 0.2 is more than 0.3 as exact values but within FloatEqual of
+	// it, so only an exact count gives the first choice a majority in
+	// round 2.
+	nearTie := RankedChoiceVoting{
+		Choices: []string{"First", "Second", "Third"},
+		Votes: []RankedChoiceVote{
+			{Choice: []int{3, 1}, Balance: 0.1},
+			{Choice: []int{1}, Balance: 0.2},
+			{Choice: []int{2}, Balance: 0.3},
+		},
+	}
+	if rounds := nearTie.GetRounds(); len(rounds) != 2 || rounds[1].Eliminated != 0 {
+		t.Errorf("Expected the count to end in round 2, got %+v", rounds)
+	}
+	if winner := nearTie.GetWinner(); winner != 1 {
+		t.Errorf("Expected winner %d, got %d", 1, winner)
+	}
+}
@@ -0,0 +1,281 @@
+package registry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/This-Is-Prince/votingSystemGo/approval"
+	"github.com/This-Is-Prince/votingSystemGo/basic"
+	"github.com/This-Is-Prince/votingSystemGo/condorcet"
+	"github.com/This-Is-Prince/votingSystemGo/positional"
+	"github.com/This-Is-Prince/votingSystemGo/quadratic"
+	"github.com/This-Is-Prince/votingSystemGo/rankedChoice"
+	"github.com/This-Is-Prince/votingSystemGo/score"
+	"github.com/This-Is-Prince/votingSystemGo/singleChoice"
+	"github.com/This-Is-Prince/votingSystemGo/star"
+	"github.com/This-Is-Prince/votingSystemGo/stv"
+	"github.com/This-Is-Prince/votingSystemGo/voting"
+	"github.com/This-Is-Prince/votingSystemGo/weighted"
+)
+
+// Constructor returns a new, empty voting object that a proposal document
+// can be unmarshaled into.
+type Constructor func() voting.VotingSystem
+
+var (
+	ErrEmptyType       = errors.New("voting type is empty")
+	ErrNilConstructor  = errors.New("voting type constructor is nil")
+	ErrDuplicateType   = errors.New("voting type is already registered")
+	ErrUnknownType     = errors.New("voting type is not registered")
+	ErrNotStreamable   = errors.New("voting type cannot be streamed")
+	ErrVotesFirst      = errors.New("votes come before the proposal type")
+	ErrFieldAfterVotes = errors.New("proposal field comes after the votes")
+)
+
+var (
+	mu           sync.RWMutex
+	constructors = make(map[string]Constructor)
+)
+
+func init() {
+	MustRegister("single-choice", func() voting.VotingSystem {
+		return &singleChoice.SingleChoiceVoting{}
+	})
+	MustRegister("approval", func() voting.VotingSystem {
+		return &approval.ApprovalVoting{}
+	})
+	MustRegister("weighted", func() voting.VotingSystem {
+		return &weighted.WeightedVoting{}
+	})
+	MustRegister("quadratic", func() voting.VotingSystem {
+		return &quadratic.QuadraticVoting{}
+	})
+	MustRegister("basic", func() voting.VotingSystem {
+		return &basic.BasicVoting{}
+	})
+	MustRegister("ranked-choice", func() voting.VotingSystem {
+		return &rankedChoice.RankedChoiceVoting{}
+	})
+	MustRegister("positional", func() voting.VotingSystem {
+		return &positional.PositionalVoting{}
+	})
+	MustRegister("condorcet", func() voting.VotingSystem {
+		return &condorcet.CondorcetVoting{}
+	})
+	MustRegister("stv", func() voting.VotingSystem {
+		return &stv.STVVoting{}
+	})
+	MustRegister("score", func() voting.VotingSystem {
+		return &score.ScoreVoting{}
+	})
+	MustRegister("star", func() voting.VotingSystem {
+		return &star.STARVoting{}
+	})
+}
+
+func Register(name string, constructor Constructor) error {
+	if name == "" {
+		return ErrEmptyType
+	}
+
+	if constructor == nil {
+		return fmt.Errorf("%w: %s", ErrNilConstructor, name)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, ok := constructors[name]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateType, name)
+	}
+
+	constructors[name] = constructor
+	return nil
+}
+
+func MustRegister(name string, constructor Constructor) {
+	if err := Register(name, constructor); err != nil {
+		panic(err)
+	}
+}
+
+func Types() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	types := []string{}
+	for name := range constructors {
+		types = append(types, name)
+	}
+	sort.Strings(types)
+
+	return types
+}
+
+func New(name string) (voting.VotingSystem, error) {
+	mu.RLock()
+	constructor, ok := constructors[name]
+	mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownType, name)
+	}
+
+	return constructor(), nil
+}
+
+// Load unmarshals a proposal document into the voting type named by its
+// "type" field.
+func Load(data []byte) (voting.VotingSystem, error) {
+	header := struct {
+		Type string `json:"type"`
+	}{}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, err
+	}
+
+	if header.Type == "" {
+		return nil, ErrEmptyType
+	}
+
+	v, err := New(header.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, fmt.Errorf("decoding %s proposal: %w", header.Type, err)
+	}
+
+	return v, nil
+}
+
+func Decode(r io.Reader) (voting.VotingSystem, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return Load(data)
+}
+
+// StreamResult is a proposal tallied by Stream. Proposal holds every field of
+// the document except its votes, which only went through Tallier.
+type StreamResult struct {
+	Proposal voting.VotingSystem
+	Tallier  voting.Tallier
+	Stats    voting.StreamStats
+}
+
+// Stream tallies a proposal document without loading its votes into memory.
+// The "type" field and every field of the proposal, such as "choices",
+// "strategies" and "decimals", must come before "votes"; one that comes
+// after is an error, while fields the proposal ignores may come anywhere.
+// Only single-choice, approval, weighted, quadratic and basic proposals can
+// be streamed. The others have no Tallier, either because their count needs
+// every ballot at once or because it does not add votes up.
+func Stream(r io.Reader) (*StreamResult, error) {
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+
+	header := map[string]json.RawMessage{}
+	result := &StreamResult{}
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key := token.(string)
+
+		if key != "votes" || result.Proposal != nil {
+			var value json.RawMessage
+			if err := dec.Decode(&value); err != nil {
+				return nil, err
+			}
+			if key == "votes" {
+				continue
+			}
+			header[key] = value
+
+			if result.Proposal != nil {
+				updated, err := streamHeader(header)
+				if err != nil {
+					return nil, err
+				}
+				if !reflect.DeepEqual(updated, result.Proposal) {
+					return nil, fmt.Errorf("%w: %q", ErrFieldAfterVotes, key)
+				}
+			}
+			continue
+		}
+
+		if _, ok := header["type"]; !ok {
+			return nil, ErrVotesFirst
+		}
+
+		streamer, err := streamHeader(header)
+		if err != nil {
+			return nil, err
+		}
+		result.Proposal = streamer.(voting.VotingSystem)
+		result.Tallier = streamer.NewTallier()
+		result.Stats, err = voting.TallyVotes(voting.NewVoteArrayDecoder(dec, streamer), result.Tallier)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := expectDelim(dec, '}'); err != nil {
+		return nil, err
+	}
+
+	if result.Proposal == nil {
+		streamer, err := streamHeader(header)
+		if err != nil {
+			return nil, err
+		}
+		result.Proposal = streamer.(voting.VotingSystem)
+		result.Tallier = streamer.NewTallier()
+	}
+
+	return result, nil
+}
+
+func streamHeader(header map[string]json.RawMessage) (voting.Streamer, error) {
+	data, err := json.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := Load(data)
+	if err != nil {
+		return nil, err
+	}
+
+	streamer, ok := v.(voting.Streamer)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrNotStreamable, v)
+	}
+
+	return streamer, nil
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if d, ok := token.(json.Delim); !ok || d != delim {
+		return fmt.Errorf("expected %v, got %v", delim, token)
+	}
+
+	return nil
+}
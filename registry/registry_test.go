@@ -0,0 +1,189 @@
+package registry
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/This-Is-Prince/votingSystemGo/approval"
+	"github.com/This-Is-Prince/votingSystemGo/singleChoice"
+	"github.com/This-Is-Prince/votingSystemGo/utils"
+	"github.com/This-Is-Prince/votingSystemGo/voting"
+)
+
+func TestRegistry(t *testing.T) {
+	for _, name := range []string{"single-choice", "approval", "weighted", "quadratic", "ranked-choice", "basic", "positional", "condorcet", "stv", "score", "star"} {
+		if _, err := New(name); err != nil {
+			t.Errorf("Expected %s to be registered, got %v", name, err)
+		}
+	}
+
+	if _, err := New("unknown"); !errors.Is(err, ErrUnknownType) {
+		t.Errorf("Expected ErrUnknownType, got %v", err)
+	}
+
+	err := Register("single-choice", func() voting.VotingSystem {
+		return &singleChoice.SingleChoiceVoting{}
+	})
+	if !errors.Is(err, ErrDuplicateType) {
+		t.Errorf("Expected ErrDuplicateType, got %v", err)
+	}
+
+	if err := Register("custom-approval", nil); !errors.Is(err, ErrNilConstructor) {
+		t.Errorf("Expected ErrNilConstructor, got %v", err)
+	}
+
+	err = Register("custom-approval", func() voting.VotingSystem {
+		return &approval.ApprovalVoting{}
+	})
+	if err != nil {
+		t.Fatalf("Expected custom-approval to register, got %v", err)
+	}
+
+	found := false
+	for _, name := range Types() {
+		if name == "custom-approval" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected custom-approval in %v", Types())
+	}
+}
+
+func TestLoad(t *testing.T) {
+	proposal := `{
+		"type": "approval",
+		"choices": ["First", "Second", "Third"],
+		"strategies": [{"name": "erc20-balance-of"}, {"name": "delegation"}],
+		"votes": [
+			{"choice": [1, 3], "balance": 3, "scores": [1, 2]},
+			{"choice": [2], "balance": 5, "scores": [4, 1]}
+		]
+	}`
+
+	v, err := Decode(strings.NewReader(proposal))
+	if err != nil {
+		t.Fatalf("Expected proposal to load, got %v", err)
+	}
+
+	if _, ok := v.(*approval.ApprovalVoting); !ok {
+		t.Fatalf("Expected *approval.ApprovalVoting, got %T", v)
+	}
+
+	expectedScores := []float64{3, 5, 3}
+	scores := v.GetScores()
+	for i, score := range scores {
+		if !utils.FloatEqual(score, expectedScores[i]) {
+			t.Errorf("Expected score %f, got %f", expectedScores[i], score)
+		}
+	}
+
+	if _, err := Load([]byte(`{"choices": []}`)); !errors.Is(err, ErrEmptyType) {
+		t.Errorf("Expected ErrEmptyType, got %v", err)
+	}
+
+	if _, err := Load([]byte(`{"type": "ranked"}`)); !errors.Is(err, ErrUnknownType) {
+		t.Errorf("Expected ErrUnknownType, got %v", err)
+	}
+
+	if _, err := Load([]byte(`{"type": "single-choice", "votes": [{"choice": "1"}]}`)); err == nil {
+		t.Errorf("Expected decoding error for malformed vote")
+	}
+}
+
+func TestStream(t *testing.T) {
+	proposals := map[string]string{
+		"single-choice": `{"type": "single-choice", "choices": ["First", "Second"], "strategies": [1, 2], "votes": [
+			{"choice": 1, "balance": 3, "scores": [1, 2]},
+			{"choice": 3, "balance": 5, "scores": [4, 1]},
+			{"choice": 2, "balance": 0.5, "scores": [0.25, 0.25]}
+		], "title": "After votes"}`,
+		"approval": `{"type": "approval", "choices": ["First", "Second"], "strategies": [1, 2], "votes": [
+			{"choice": [1, 2], "balance": 3, "scores": [1, 2]},
+			{"choice": [2], "balance": 0.5, "scores": [0.25, 0.25]}
+		]}`,
+		"weighted": `{"type": "weighted", "choices": ["First", "Second"], "strategies": [1, 2], "votes": [
+			{"choice": {"1": 1, "2": 3}, "balance": 3, "scores": [1, 2]},
+			{"choice": {"2": 1}, "balance": 0.5, "scores": [0.25, 0.25]}
+		]}`,
+		"quadratic": `{"type": "quadratic", "choices": ["First", "Second"], "strategies": [1, 2], "votes": [
+			{"choice": {"1": 1, "2": 2}, "balance": 3, "scores": [1, 2]},
+			{"choice": {"2": 1}, "balance": 0.5, "scores": [0.25, 0.25]}
+		]}`,
+	}
+
+	for name, proposal := range proposals {
+		expected, err := Decode(strings.NewReader(proposal))
+		if err != nil {
+			t.Fatalf("%s: expected proposal to load, got %v", name, err)
+		}
+
+		result, err := Stream(strings.NewReader(proposal))
+		if err != nil {
+			t.Fatalf("%s: expected proposal to stream, got %v", name, err)
+		}
+
+		if len(result.Proposal.GetVotes()) != 0 {
+			t.Errorf("%s: expected streamed votes to stay out of the proposal, got %d", name, len(result.Proposal.GetVotes()))
+		}
+		if !reflect.DeepEqual(result.Tallier.GetScores(), expected.GetScores()) {
+			t.Errorf("%s: expected scores %v, got %v", name, expected.GetScores(), result.Tallier.GetScores())
+		}
+		if !reflect.DeepEqual(result.Tallier.GetScoresByStrategy(), expected.GetScoresByStrategy()) {
+			t.Errorf("%s: expected scores by strategy %v, got %v", name, expected.GetScoresByStrategy(), result.Tallier.GetScoresByStrategy())
+		}
+		if result.Tallier.GetScoresTotal() != expected.GetScoresTotal() {
+			t.Errorf("%s: expected scores total %v, got %v", name, expected.GetScoresTotal(), result.Tallier.GetScoresTotal())
+		}
+
+		valid := len(voting.GetValidVotes(expected))
+		if result.Stats.Votes != len(expected.GetVotes()) || result.Stats.Rejected != len(expected.GetVotes())-valid {
+			t.Errorf("%s: expected %d votes with %d rejected, got %+v", name, len(expected.GetVotes()), len(expected.GetVotes())-valid, result.Stats)
+		}
+	}
+
+	if _, err := Stream(strings.NewReader(`{"votes": [], "type": "approval"}`)); !errors.Is(err, ErrVotesFirst) {
+		t.Errorf("Expected ErrVotesFirst, got %v", err)
+	}
+
+	if _, err := Stream(strings.NewReader(`{"type": "ranked-choice", "choices": ["First"], "votes": []}`)); !errors.Is(err, ErrNotStreamable) {
+		t.Errorf("Expected ErrNotStreamable, got %v", err)
+	}
+
+	if _, err := Stream(strings.NewReader(`{"type": "single-choice", "votes": [{"choice": "1"}]}`)); err == nil {
+		t.Errorf("Expected decoding error for malformed vote")
+	}
+
+	if _, err := Stream(strings.NewReader(`{"type": "single-choice", "votes": {}}`)); !errors.Is(err, voting.ErrNotVoteArray) {
+		t.Errorf("Expected ErrNotVoteArray, got %v", err)
+	}
+
+	for _, late := range []string{
+		`{"type": "single-choice", "choices": ["First", "Second"], "votes": [{"choice": 1, "balance": 3, "scores": [3]}], "strategies": [1]}`,
+		`{"type": "single-choice", "choices": ["First", "Second"], "strategies": [1], "votes": [], "decimals": 18}`,
+	} {
+		if _, err := Stream(strings.NewReader(late)); !errors.Is(err, ErrFieldAfterVotes) {
+			t.Errorf("Expected ErrFieldAfterVotes for %s, got %v", late, err)
+		}
+	}
+
+	// Base units are counted in the proposal's decimals, as Load does.
+	units := `{"type": "single-choice", "choices": ["First", "Second"], "strategies": [1], "decimals": 18, "votes": [
+		{"choice": 1, "balanceUnits": 5000000000000000000, "scoresUnits": [5000000000000000000]}
+	]}`
+	expected, err := Decode(strings.NewReader(units))
+	if err != nil {
+		t.Fatalf("Expected base-unit proposal to load, got %v", err)
+	}
+	streamed, err := Stream(strings.NewReader(units))
+	if err != nil || !reflect.DeepEqual(streamed.Tallier.GetScores(), expected.GetScores()) {
+		t.Errorf("Expected streamed base-unit scores %v, got %+v, %v", expected.GetScores(), streamed, err)
+	}
+
+	result, err := Stream(strings.NewReader(`{"type": "basic", "strategies": [1]}`))
+	if err != nil || result.Stats.Votes != 0 || result.Tallier.GetScoresTotal() != 0 {
+		t.Errorf("Expected an empty tally for a proposal without votes, got %+v, %v", result, err)
+	}
+}
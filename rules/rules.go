@@ -0,0 +1,156 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/This-Is-Prince/votingSystemGo/voting"
+)
+
+type Majority int
+
+const (
+	// NoMajorityRule passes a proposal whatever share its choice has.
+	NoMajorityRule Majority = iota
+	SimpleMajority
+	Supermajority
+)
+
+const DefaultSupermajorityRatio = float64(2) / float64(3)
+
+type Reason string
+
+const (
+	ReasonPassed           Reason = "passed"
+	ReasonInvalidRules     Reason = "invalid-rules"
+	ReasonNoVotes          Reason = "no-votes"
+	ReasonQuorumNotReached Reason = "quorum-not-reached"
+	ReasonNoMajority       Reason = "no-majority"
+	ReasonThresholdNotMet  Reason = "threshold-not-met"
+)
+
+// Rules decide whether a proposal passes. Quorum is an absolute minimum
+// participation and QuorumRatio a minimum share of Supply; participation is
+// the scores total of the vote. Majority is measured on the ForChoice when
+// it is set, otherwise on the leading choice, as a share of all scores
+// except the AbstainChoice. A simple majority needs more than half, a
+// supermajority at least SupermajorityRatio (2/3 when zero). ForThreshold is
+// an extra minimum share for the ForChoice only.
+type Rules struct {
+	Quorum             float64  `json:"quorum"`
+	QuorumRatio        float64  `json:"quorumRatio"`
+	Supply             float64  `json:"supply"`
+	Majority           Majority `json:"majority"`
+	SupermajorityRatio float64  `json:"supermajorityRatio"`
+	ForChoice          int      `json:"forChoice"`
+	ForThreshold       float64  `json:"forThreshold"`
+	AbstainChoice      int      `json:"abstainChoice"`
+}
+
+// Verdict explains a pass/fail decision. Choice is the 1-based choice the
+// majority and threshold were measured on and Share its share of the
+// decisive scores.
+type Verdict struct {
+	Passed        bool    `json:"passed"`
+	Reason        Reason  `json:"reason"`
+	Message       string  `json:"message"`
+	Participation float64 `json:"participation"`
+	Choice        int     `json:"choice"`
+	Share         float64 `json:"share"`
+}
+
+func (r Rules) Evaluate(v voting.VotingSystem) Verdict {
+	choices := len(v.GetChoices())
+	if r.ForChoice < 0 || r.ForChoice > choices || r.AbstainChoice < 0 || r.AbstainChoice > choices {
+		return fail(ReasonInvalidRules, "for or abstain choice is out of range")
+	}
+
+	if r.ForChoice > 0 && r.ForChoice == r.AbstainChoice {
+		return fail(ReasonInvalidRules, "for and abstain choices are the same")
+	}
+
+	if r.Quorum < 0 || r.QuorumRatio < 0 {
+		return fail(ReasonInvalidRules, "quorum and quorum ratio cannot be negative")
+	}
+
+	switch r.Majority {
+	case NoMajorityRule, SimpleMajority, Supermajority:
+	default:
+		return fail(ReasonInvalidRules, fmt.Sprintf("unknown majority rule %d", r.Majority))
+	}
+
+	if r.QuorumRatio > 0 && r.Supply <= 0 {
+		return fail(ReasonInvalidRules, "quorum ratio requires a positive supply")
+	}
+
+	if r.ForThreshold > 0 && r.ForChoice == 0 {
+		return fail(ReasonInvalidRules, "for threshold requires a for choice")
+	}
+
+	verdict := Verdict{
+		Participation: v.GetScoresTotal(),
+	}
+
+	if verdict.Participation <= 0 {
+		return with(verdict, ReasonNoVotes, "no votes were cast")
+	}
+
+	if r.Quorum > 0 && verdict.Participation < r.Quorum {
+		return with(verdict, ReasonQuorumNotReached, fmt.Sprintf("participation %g is below quorum %g", verdict.Participation, r.Quorum))
+	}
+
+	if required := r.QuorumRatio * r.Supply; r.QuorumRatio > 0 && verdict.Participation < required {
+		return with(verdict, ReasonQuorumNotReached, fmt.Sprintf("participation %g is below %g%% of supply %g", verdict.Participation, r.QuorumRatio*100, r.Supply))
+	}
+
+	scores := v.GetScores()
+	decisive := float64(0)
+	for idx, score := range scores {
+		if idx+1 == r.AbstainChoice {
+			continue
+		}
+		decisive = decisive + score
+		if r.ForChoice == 0 && (verdict.Choice == 0 || score > scores[verdict.Choice-1]) {
+			verdict.Choice = idx + 1
+		}
+	}
+
+	if r.ForChoice > 0 {
+		verdict.Choice = r.ForChoice
+	}
+
+	if verdict.Choice > 0 && decisive > 0 {
+		verdict.Share = scores[verdict.Choice-1] / decisive
+	}
+
+	switch r.Majority {
+	case SimpleMajority:
+		if verdict.Share <= 0.5 {
+			return with(verdict, ReasonNoMajority, fmt.Sprintf("choice %d has %g of the vote, not more than half", verdict.Choice, verdict.Share))
+		}
+	case Supermajority:
+		ratio := r.SupermajorityRatio
+		if ratio == 0 {
+			ratio = DefaultSupermajorityRatio
+		}
+		if verdict.Share < ratio {
+			return with(verdict, ReasonNoMajority, fmt.Sprintf("choice %d has %g of the vote, below the %g supermajority", verdict.Choice, verdict.Share, ratio))
+		}
+	}
+
+	if r.ForThreshold > 0 && verdict.Share < r.ForThreshold {
+		return with(verdict, ReasonThresholdNotMet, fmt.Sprintf("choice %d has %g of the vote, below the %g threshold", verdict.Choice, verdict.Share, r.ForThreshold))
+	}
+
+	verdict.Passed = true
+	return with(verdict, ReasonPassed, "all rules were met")
+}
+
+func fail(reason Reason, message string) Verdict {
+	return with(Verdict{}, reason, message)
+}
+
+func with(verdict Verdict, reason Reason, message string) Verdict {
+	verdict.Reason = reason
+	verdict.Message = message
+	return verdict
+}
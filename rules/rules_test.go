@@ -0,0 +1,76 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/This-Is-Prince/votingSystemGo/basic"
+	"github.com/This-Is-Prince/votingSystemGo/singleChoice"
+	"github.com/This-Is-Prince/votingSystemGo/utils"
+)
+
+func TestRules(t *testing.T) {
+	basicVoting := &basic.BasicVoting{
+		Votes: []basic.BasicVote{
+			{Choice: basic.For, Balance: float64(60)},
+			{Choice: basic.Against, Balance: float64(30)},
+			{Choice: basic.Abstain, Balance: float64(110)},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		rules  Rules
+		reason Reason
+	}{
+		{"no rules", Rules{}, ReasonPassed},
+		{"absolute quorum reached", Rules{Quorum: float64(200)}, ReasonPassed},
+		{"absolute quorum missed", Rules{Quorum: float64(201)}, ReasonQuorumNotReached},
+		{"supply quorum reached", Rules{QuorumRatio: float64(0.2), Supply: float64(1000)}, ReasonPassed},
+		{"supply quorum missed", Rules{QuorumRatio: float64(0.25), Supply: float64(1000)}, ReasonQuorumNotReached},
+		{"supply quorum without supply", Rules{QuorumRatio: float64(0.25)}, ReasonInvalidRules},
+		{"simple majority counting abstain", Rules{Majority: SimpleMajority, ForChoice: basic.For}, ReasonNoMajority},
+		{"simple majority", Rules{Majority: SimpleMajority, ForChoice: basic.For, AbstainChoice: basic.Abstain}, ReasonPassed},
+		{"supermajority", Rules{Majority: Supermajority, ForChoice: basic.For, AbstainChoice: basic.Abstain}, ReasonPassed},
+		{"custom supermajority", Rules{Majority: Supermajority, SupermajorityRatio: float64(0.75), ForChoice: basic.For, AbstainChoice: basic.Abstain}, ReasonNoMajority},
+		{"for threshold met", Rules{ForChoice: basic.For, ForThreshold: float64(0.6), AbstainChoice: basic.Abstain}, ReasonPassed},
+		{"for threshold missed", Rules{ForChoice: basic.For, ForThreshold: float64(0.7), AbstainChoice: basic.Abstain}, ReasonThresholdNotMet},
+		{"for threshold without for choice", Rules{ForThreshold: float64(0.7)}, ReasonInvalidRules},
+		{"for choice out of range", Rules{ForChoice: 4}, ReasonInvalidRules},
+		{"negative quorum", Rules{Quorum: float64(-1)}, ReasonInvalidRules},
+		{"negative quorum ratio", Rules{QuorumRatio: float64(-0.5), Supply: float64(1000)}, ReasonInvalidRules},
+		{"unknown majority", Rules{Majority: Majority(3)}, ReasonInvalidRules},
+	}
+
+	for _, test := range tests {
+		verdict := test.rules.Evaluate(basicVoting)
+		if verdict.Reason != test.reason {
+			t.Errorf("%s: expected reason %s, got %s (%s)", test.name, test.reason, verdict.Reason, verdict.Message)
+		}
+		if verdict.Passed != (test.reason == ReasonPassed) {
+			t.Errorf("%s: expected passed to be %v, got %v", test.name, test.reason == ReasonPassed, verdict.Passed)
+		}
+	}
+
+	singleChoiceVoting := &singleChoice.SingleChoiceVoting{
+		Choices: []string{"First", "Second", "Third"},
+		Votes: []singleChoice.SingleChoiceVote{
+			{Choice: 1, Balance: float64(2)},
+			{Choice: 2, Balance: float64(5)},
+			{Choice: 3, Balance: float64(3)},
+		},
+	}
+
+	verdict := Rules{Majority: SimpleMajority}.Evaluate(singleChoiceVoting)
+	if verdict.Passed || verdict.Choice != 2 || !utils.FloatEqual(verdict.Share, float64(0.5)) {
+		t.Errorf("Expected choice 2 to fall short of a majority with half the vote, got %+v", verdict)
+	}
+
+	singleChoiceVoting.Votes[0].Balance = float64(1)
+	if verdict := (Rules{Majority: SimpleMajority}).Evaluate(singleChoiceVoting); !verdict.Passed {
+		t.Errorf("Expected choice 2 to pass with a simple majority, got %+v", verdict)
+	}
+
+	if verdict := (Rules{}).Evaluate(&singleChoice.SingleChoiceVoting{}); verdict.Reason != ReasonNoVotes {
+		t.Errorf("Expected reason %s, got %s", ReasonNoVotes, verdict.Reason)
+	}
+}
@@ -0,0 +1,335 @@
+package score
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/This-Is-Prince/votingSystemGo/utils"
+	"github.com/This-Is-Prince/votingSystemGo/voting"
+)
+
+// Unrated decides how a choice left off a ballot is counted.
+type Unrated string
+
+const (
+	// Blank ignores the ballot for a choice it does not rate.
+	Blank Unrated = "blank"
+	// Minimum counts a choice the ballot does not rate at Min.
+	Minimum Unrated = "minimum"
+)
+
+// Aggregation decides how the ratings of a choice are combined.
+type Aggregation string
+
+const (
+	// Sum adds up each rating times the ballot's balance.
+	Sum Aggregation = "sum"
+	// Average divides that sum by the balance of the ballots counted for
+	// the choice.
+	Average Aggregation = "average"
+)
+
+// DefaultMax is the top of the scale when neither Min nor Max is set.
+const DefaultMax = 5
+
+// ScoreVote holds its balance as a float only. It has no exact or
+// base-unit amounts, so a proposal in base units must be converted to
+// tokens before it is counted; "balanceUnits" would be silently ignored.
+type ScoreVote struct {
+	Choice  ScoreChoice `json:"choice"`
+	Balance float64     `json:"balance"`
+	Scores  []float64   `json:"scores"`
+	Voter   string      `json:"voter,omitempty"`
+	Created int64       `json:"created,omitempty"`
+}
+
+// ScoreChoice maps 1-based choices to their ratings.
+type ScoreChoice map[string]float64
+
+// ScoreVoting rates every choice independently on a scale from Min to Max.
+// Unlike weighted voting, a rating is not a share of the voter's power: each
+// rating counts in full, times the ballot's balance. Unrated defaults to
+// Blank and Aggregation to Sum. Score proposals have no Tallier, so they
+// are neither streamed nor tallied in parallel.
+type ScoreVoting struct {
+	Choices     []string          `json:"choices"`
+	Votes       []ScoreVote       `json:"votes"`
+	Strategies  []voting.Strategy `json:"strategies"`
+	Min         float64           `json:"min"`
+	Max         float64           `json:"max"`
+	Unrated     Unrated           `json:"unrated,omitempty"`
+	Aggregation Aggregation       `json:"aggregation,omitempty"`
+}
+
+var (
+	_ voting.VotingSystem     = (*ScoreVoting)(nil)
+	_ voting.PercentageSystem = (*ScoreVoting)(nil)
+)
+
+func (v ScoreVote) GetChoice() interface{} {
+	return v.Choice
+}
+
+func (v ScoreVote) GetBalance() float64 {
+	return v.Balance
+}
+
+func (v ScoreVote) GetScores() []float64 {
+	return v.Scores
+}
+
+func (v ScoreVote) GetVoter() string {
+	return v.Voter
+}
+
+func (v ScoreVote) GetCreated() int64 {
+	return v.Created
+}
+
+func (v ScoreVote) Supports(choice int) bool {
+	return v.Choice[strconv.Itoa(choice)] > 0
+}
+
+func ValidateChoice(voteChoice ScoreChoice, proposalChoices []string) error {
+	if len(voteChoice) == 0 {
+		return voting.NewValidationError(voting.CodeEmptyChoice, "no choice is rated")
+	}
+
+	for _, k := range utils.SortedChoiceKeys(voteChoice) {
+		numKey, err := strconv.ParseInt(k, 10, 64)
+		if err != nil {
+			return voting.NewValidationError(voting.CodeInvalidChoiceKey, "choice %q is not a number", k)
+		}
+
+		if numKey <= 0 || int(numKey) > len(proposalChoices) {
+			return voting.NewValidationError(voting.CodeChoiceOutOfRange, "choice %d is not between 1 and %d", numKey, len(proposalChoices))
+		}
+	}
+
+	return nil
+}
+
+func IsValidChoice(voteChoice ScoreChoice, proposalChoices []string) bool {
+	return ValidateChoice(voteChoice, proposalChoices) == nil
+}
+
+// ValidateRatings checks that every rating is a finite number from min to
+// max.
+func ValidateRatings(voteChoice ScoreChoice, min float64, max float64) error {
+	for _, k := range utils.SortedChoiceKeys(voteChoice) {
+		rating := voteChoice[k]
+		if math.IsNaN(rating) || math.IsInf(rating, 0) || rating < min || rating > max {
+			return voting.NewValidationError(voting.CodeRatingOutOfRange, "choice %s is rated %v, not between %v and %v", k, rating, min, max)
+		}
+	}
+
+	return nil
+}
+
+// GetRange returns the lowest and highest rating, 0 to DefaultMax when
+// neither is set.
+func (v *ScoreVoting) GetRange() (float64, float64) {
+	if v.Min == 0 && v.Max == 0 {
+		return 0, DefaultMax
+	}
+	return v.Min, v.Max
+}
+
+// validateProposal reports a scale, option or strategy a count cannot use.
+func (v *ScoreVoting) validateProposal() error {
+	min, max := v.GetRange()
+	if math.IsNaN(min) || math.IsInf(min, 0) || math.IsNaN(max) || math.IsInf(max, 0) || min >= max {
+		return voting.NewValidationError(voting.CodeInvalidVote, "scale from %v to %v is not a finite, increasing range", min, max)
+	}
+
+	switch v.Unrated {
+	case "", Blank, Minimum:
+	default:
+		return voting.NewValidationError(voting.CodeInvalidVote, "unknown unrated option %q", v.Unrated)
+	}
+
+	switch v.Aggregation {
+	case "", Sum, Average:
+	default:
+		return voting.NewValidationError(voting.CodeInvalidVote, "unknown aggregation %q", v.Aggregation)
+	}
+
+	return voting.ValidateStrategies(v.Strategies)
+}
+
+func (v *ScoreVoting) GetChoices() []string {
+	return v.Choices
+}
+
+func (v *ScoreVoting) GetStrategies() []voting.Strategy {
+	return v.Strategies
+}
+
+func (v *ScoreVoting) GetVotes() []voting.Vote {
+	return voting.ToVotes(v.Votes)
+}
+
+func (v *ScoreVoting) Deduplicate(policy voting.DedupPolicy) []voting.Vote {
+	votes, superseded := voting.Deduplicate(v.Votes, policy)
+	v.Votes = votes
+	return voting.ToVotes(superseded)
+}
+
+func (v *ScoreVoting) IsValidVote(vote voting.Vote) bool {
+	return v.ValidateVote(vote) == nil
+}
+
+func (v *ScoreVoting) ValidateVote(vote voting.Vote) error {
+	scoreVote, ok := vote.(ScoreVote)
+	if !ok {
+		return voting.NewValidationError(voting.CodeInvalidVoteType, "expected %T, got %T", ScoreVote{}, vote)
+	}
+
+	return v.validateVote(scoreVote)
+}
+
+// validateVote also rejects every vote of a misconfigured proposal, so it
+// counts nothing.
+func (v *ScoreVoting) validateVote(vote ScoreVote) error {
+	if err := v.validateProposal(); err != nil {
+		return err
+	}
+
+	if err := ValidateChoice(vote.Choice, v.Choices); err != nil {
+		return err
+	}
+
+	min, max := v.GetRange()
+	if err := ValidateRatings(vote.Choice, min, max); err != nil {
+		return err
+	}
+
+	return voting.ValidatePower(vote.Balance, vote.Scores, len(v.Strategies))
+}
+
+func (v *ScoreVoting) power(vote ScoreVote) (float64, []float64) {
+	return voting.EffectivePower(v.Strategies, vote.Balance, vote.Scores)
+}
+
+func (v *ScoreVoting) GetValidVotes() []ScoreVote {
+	return utils.Filter(v.Votes, func(vote ScoreVote) bool {
+		return v.validateVote(vote) == nil
+	})
+}
+
+// Rating returns the rating vote gives the 1-based choice, and false when
+// the ballot leaves it blank and does not count for it.
+func (v *ScoreVoting) Rating(vote ScoreVote, choice int) (float64, bool) {
+	if rating, ok := vote.Choice[strconv.Itoa(choice)]; ok {
+		return rating, true
+	}
+
+	if v.Unrated == Minimum {
+		min, _ := v.GetRange()
+		return min, true
+	}
+	return 0, false
+}
+
+// GetScoresTotal returns the balance of the valid votes, not the ratings
+// they handed out.
+func (v *ScoreVoting) GetScoresTotal() float64 {
+	scoresTotal := utils.Accumulator{}
+	for _, vote := range v.GetValidVotes() {
+		balance, _ := v.power(vote)
+		scoresTotal.Add(balance)
+	}
+	return scoresTotal.Float64()
+}
+
+func (v *ScoreVoting) GetScores() []float64 {
+	scores, _ := v.aggregate(v.balance)
+	return scores
+}
+
+// GetPercentages returns each choice's share of the summed scores, counted
+// from Min so a scale below zero has no negative shares, or with Average,
+// where its average falls between Min and Max. A choice no ballot counts for
+// gets 0.
+func (v *ScoreVoting) GetPercentages() []float64 {
+	percentages, weights := v.aggregate(v.balance)
+	min, max := v.GetRange()
+	if v.Aggregation != Average {
+		total := utils.Accumulator{}
+		for idx := range percentages {
+			percentages[idx] -= min * weights[idx]
+			total.Add(percentages[idx])
+		}
+
+		sum := total.Float64()
+		for idx := range percentages {
+			if sum != 0 {
+				percentages[idx] /= sum
+			}
+		}
+		return percentages
+	}
+
+	for idx := range percentages {
+		if weights[idx] == 0 {
+			continue
+		}
+		percentages[idx] = (percentages[idx] - min) / (max - min)
+	}
+	return percentages
+}
+
+// GetScoresByStrategy aggregates the ratings once per strategy, weighting
+// each ballot by its score for that strategy.
+func (v *ScoreVoting) GetScoresByStrategy() [][]float64 {
+	scoresByStrategy := [][]float64{}
+	for range v.Choices {
+		scoresByStrategy = append(scoresByStrategy, make([]float64, len(v.Strategies)))
+	}
+
+	for idx := range v.Strategies {
+		scores, _ := v.aggregate(func(vote ScoreVote) float64 {
+			_, scores := v.power(vote)
+			return scores[idx]
+		})
+		for choice, score := range scores {
+			scoresByStrategy[choice][idx] = score
+		}
+	}
+
+	return scoresByStrategy
+}
+
+func (v *ScoreVoting) balance(vote ScoreVote) float64 {
+	balance, _ := v.power(vote)
+	return balance
+}
+
+// aggregate returns the score of every choice and the weight of the ballots
+// counted for it.
+func (v *ScoreVoting) aggregate(weight func(vote ScoreVote) float64) ([]float64, []float64) {
+	sums := make([]utils.Accumulator, len(v.Choices))
+	weights := make([]utils.Accumulator, len(v.Choices))
+
+	for _, vote := range v.GetValidVotes() {
+		w := weight(vote)
+		for idx := range v.Choices {
+			if rating, ok := v.Rating(vote, idx+1); ok {
+				sums[idx].Add(rating * w)
+				weights[idx].Add(w)
+			}
+		}
+	}
+
+	scores, counted := utils.AccumulatorsToFloats(sums), utils.AccumulatorsToFloats(weights)
+	if v.Aggregation == Average {
+		for idx, w := range counted {
+			if w == 0 {
+				scores[idx] = 0
+				continue
+			}
+			scores[idx] /= w
+		}
+	}
+	return scores, counted
+}
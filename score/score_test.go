@@ -0,0 +1,159 @@
+package score
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/This-Is-Prince/votingSystemGo/utils"
+	"github.com/This-Is-Prince/votingSystemGo/voting"
+)
+
+func TestScoreVoting(t *testing.T) {
+	choices := []string{"First", "Second", "Third"}
+	votes := []ScoreVote{
+		{
+			Choice:  ScoreChoice{"1": 10, "2": 5},
+			Balance: float64(2),
+			Scores:  []float64{float64(1), float64(1)},
+		},
+		{
+			Choice:  ScoreChoice{"2": 8, "3": 4},
+			Balance: float64(3),
+			Scores:  []float64{float64(3), float64(0)},
+		},
+		{
+			Choice:  ScoreChoice{"1": 1, "3": 10},
+			Balance: float64(1),
+			Scores:  []float64{float64(0), float64(1)},
+		},
+		{
+			Choice:  ScoreChoice{"1": 11},
+			Balance: float64(1),
+			Scores:  []float64{float64(1), float64(0)},
+		},
+		{
+			Choice:  ScoreChoice{"4": 1},
+			Balance: float64(1),
+			Scores:  []float64{float64(1), float64(0)},
+		},
+	}
+	scoreVoting := ScoreVoting{
+		Choices:    choices,
+		Votes:      votes,
+		Strategies: []voting.Strategy{{Name: "erc20-balance-of"}, {Name: "delegation"}},
+		Min:        1,
+		Max:        10,
+	}
+
+	validVotes := scoreVoting.GetValidVotes()
+	if len(validVotes) != len(votes)-2 {
+		t.Errorf("Expected %d valid votes, got %d", len(votes)-2, len(validVotes))
+	}
+
+	expectedScoresTotal := float64(6)
+	scoresTotal := scoreVoting.GetScoresTotal()
+	if !utils.FloatEqual(scoresTotal, expectedScoresTotal) {
+		t.Errorf("Expected scores total to be %f, got %f", expectedScoresTotal, scoresTotal)
+	}
+
+	// Counting unrated choices at the minimum of 1 adds the balance of
+	// every ballot that leaves a choice blank; averaging divides by the
+	// balance counted for each choice.
+	tests := []struct {
+		unrated                  Unrated
+		aggregation              Aggregation
+		expectedScores           []float64
+		expectedScoresByStrategy [][]float64
+	}{
+		{
+			"",
+			"",
+			[]float64{21, 34, 22},
+			[][]float64{{10, 11}, {29, 5}, {12, 10}},
+		},
+		{
+			Minimum,
+			Sum,
+			[]float64{24, 35, 24},
+			[][]float64{{13, 11}, {29, 6}, {13, 11}},
+		},
+		{
+			Blank,
+			Average,
+			[]float64{7, 6.8, 5.5},
+			[][]float64{{10, 5.5}, {7.25, 5}, {4, 10}},
+		},
+		{
+			Minimum,
+			Average,
+			[]float64{4, 35.0 / 6, 4},
+			[][]float64{{13.0 / 4, 5.5}, {29.0 / 4, 3}, {13.0 / 4, 5.5}},
+		},
+	}
+
+	for _, test := range tests {
+		scoreVoting.Unrated = test.unrated
+		scoreVoting.Aggregation = test.aggregation
+
+		scores := scoreVoting.GetScores()
+		for idx, score := range scores {
+			if !utils.FloatEqual(score, test.expectedScores[idx]) {
+				t.Errorf("%q/%q: expected score %f for choice %d, got %f", test.unrated, test.aggregation, test.expectedScores[idx], idx+1, score)
+			}
+		}
+
+		scoresByStrategy := scoreVoting.GetScoresByStrategy()
+		for idx, choiceScores := range scoresByStrategy {
+			for sIdx, score := range choiceScores {
+				if !utils.FloatEqual(score, test.expectedScoresByStrategy[idx][sIdx]) {
+					t.Errorf("%q/%q: expected score %f for choice %d and strategy %d, got %f", test.unrated, test.aggregation, test.expectedScoresByStrategy[idx][sIdx], idx+1, sIdx+1, score)
+				}
+			}
+		}
+	}
+
+	// Percentages are shares of the summed scores, or averages placed on the
+	// scale; a choice nobody rates gets 0.
+	single := ScoreVoting{Choices: choices, Votes: []ScoreVote{{Choice: ScoreChoice{"1": 5, "2": 3}, Balance: 2}}}
+	for aggregation, expected := range map[Aggregation][]float64{Sum: {0.625, 0.375, 0}, Average: {1, 0.6, 0}} {
+		single.Aggregation = aggregation
+		if percentages := voting.NewResult(&single).Percentages; !reflect.DeepEqual(percentages, expected) {
+			t.Errorf("%s: expected percentages %v, got %v", aggregation, expected, percentages)
+		}
+	}
+
+	// On a scale below zero the least negative score still wins.
+	negative := ScoreVoting{Choices: choices[:2], Min: -2, Max: 2, Votes: []ScoreVote{{Choice: ScoreChoice{"1": -1, "2": -2}, Balance: 2}}}
+	if result := voting.NewResult(&negative); !reflect.DeepEqual(result.Scores, []float64{-2, -4}) || result.Winner != 1 || result.Tie {
+		t.Errorf("Expected choice 1 to win with scores [-2 -4], got %+v", result)
+	} else if !reflect.DeepEqual(result.Percentages, []float64{1, 0}) {
+		t.Errorf("Expected percentages counted from the minimum, got %v", result.Percentages)
+	}
+
+	// A misconfigured proposal counts no vote at all.
+	for _, broken := range []ScoreVoting{
+		{Choices: choices, Votes: votes, Strategies: scoreVoting.Strategies, Min: 10, Max: 1},
+		{Choices: choices, Votes: votes, Strategies: scoreVoting.Strategies, Max: 10, Unrated: "zero"},
+		{Choices: choices, Votes: votes, Strategies: scoreVoting.Strategies, Max: 10, Aggregation: "median"},
+	} {
+		if validVotes := broken.GetValidVotes(); len(validVotes) != 0 {
+			t.Errorf("Expected no valid votes for %+v, got %d", broken, len(validVotes))
+		}
+		if scores := broken.GetScores(); !reflect.DeepEqual(scores, []float64{0, 0, 0}) {
+			t.Errorf("Expected no scores for %+v, got %v", broken, scores)
+		}
+	}
+
+	// Without a scale the ratings run from 0 to DefaultMax.
+	defaultVoting := ScoreVoting{Choices: choices, Votes: votes[:1]}
+	if min, max := defaultVoting.GetRange(); min != 0 || max != DefaultMax {
+		t.Errorf("Expected range 0 to %d, got %v to %v", DefaultMax, min, max)
+	}
+	if err := defaultVoting.ValidateVote(votes[0]); voting.ErrorCodeOf(err) != voting.CodeRatingOutOfRange {
+		t.Errorf("Expected code %s, got %v", voting.CodeRatingOutOfRange, err)
+	}
+
+	if !votes[0].Supports(1) || votes[0].Supports(3) {
+		t.Errorf("Expected the first vote to support only rated choices")
+	}
+}
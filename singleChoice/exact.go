@@ -0,0 +1,57 @@
+package singleChoice
+
+import (
+	"math/big"
+
+	"github.com/This-Is-Prince/votingSystemGo/utils"
+	"github.com/This-Is-Prince/votingSystemGo/voting"
+)
+
+var _ voting.ExactVotingSystem = (*SingleChoiceVoting)(nil)
+
+func (v SingleChoiceVote) GetExactBalance(decimals int) *big.Rat {
+	return utils.ExactValue(v.BalanceUnits, v.ExactBalance, v.Balance, decimals)
+}
+
+func (v SingleChoiceVote) GetExactScores(decimals int) []*big.Rat {
+	return utils.ExactValues(v.ScoresUnits, v.ExactScores, v.Scores, decimals)
+}
+
+func (v *SingleChoiceVoting) GetScoresTotalExact() *big.Rat {
+	scoresTotal := new(big.Rat)
+	for _, vote := range v.GetValidVotes() {
+		balance, _ := v.exactPower(vote)
+		scoresTotal.Add(scoresTotal, balance)
+	}
+	return scoresTotal
+}
+
+func (v *SingleChoiceVoting) GetScoresExact() []*big.Rat {
+	scores := utils.NewRats(len(v.Choices))
+
+	for _, vote := range v.GetValidVotes() {
+		choice := vote.Choice
+		balance, _ := v.exactPower(vote)
+		scores[choice-1].Add(scores[choice-1], balance)
+	}
+
+	return scores
+}
+
+func (v *SingleChoiceVoting) GetScoresByStrategyExact() [][]*big.Rat {
+	scoresByStrategy := utils.NewRatMatrix(len(v.Choices), len(v.Strategies))
+
+	for _, vote := range v.GetValidVotes() {
+		choice := vote.Choice
+		_, scores := v.exactPower(vote)
+		for idx, score := range scores {
+			scoresByStrategy[choice-1][idx].Add(scoresByStrategy[choice-1][idx], score)
+		}
+	}
+
+	return scoresByStrategy
+}
+
+func (v *SingleChoiceVoting) exactPower(vote SingleChoiceVote) (*big.Rat, []*big.Rat) {
+	return voting.EffectivePowerExact(v.Strategies, vote.GetExactBalance(v.Decimals), vote.GetExactScores(v.Decimals))
+}
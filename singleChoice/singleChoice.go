@@ -1,73 +1,162 @@
 package singleChoice
 
 import (
-	"github.com/thoas/go-funk"
+	"math/big"
+
+	"github.com/This-Is-Prince/votingSystemGo/utils"
+	"github.com/This-Is-Prince/votingSystemGo/voting"
 )
 
 type SingleChoiceVote struct {
-	Choice  int       `json:"choice"`
-	Balance float64   `json:"balance"`
-	Scores  []float64 `json:"scores"`
+	Choice       int        `json:"choice"`
+	Balance      float64    `json:"balance"`
+	Scores       []float64  `json:"scores"`
+	ExactBalance *big.Rat   `json:"exactBalance,omitempty"`
+	ExactScores  []*big.Rat `json:"exactScores,omitempty"`
+	BalanceUnits *big.Int   `json:"balanceUnits,omitempty"`
+	ScoresUnits  []*big.Int `json:"scoresUnits,omitempty"`
+	Voter        string     `json:"voter,omitempty"`
+	Created      int64      `json:"created,omitempty"`
 }
 
 type SingleChoiceVoting struct {
 	Choices    []string           `json:"choices"`
 	Votes      []SingleChoiceVote `json:"votes"`
-	Strategies []interface{}      `json:"strategies"`
+	Strategies []voting.Strategy  `json:"strategies"`
+	Decimals   int                `json:"decimals,omitempty"`
+}
+
+var _ voting.VotingSystem = (*SingleChoiceVoting)(nil)
+
+func (v SingleChoiceVote) GetChoice() interface{} {
+	return v.Choice
+}
+
+func (v SingleChoiceVote) GetBalance() float64 {
+	return v.Balance
+}
+
+func (v SingleChoiceVote) GetScores() []float64 {
+	return v.Scores
+}
+
+func (v SingleChoiceVote) GetVoter() string {
+	return v.Voter
+}
+
+func (v SingleChoiceVote) GetCreated() int64 {
+	return v.Created
+}
+
+func (v SingleChoiceVote) Supports(choice int) bool {
+	return v.Choice == choice
+}
+
+func ValidateChoice(voteChoice int, proposalChoices []string) error {
+	if voteChoice <= 0 || voteChoice > len(proposalChoices) {
+		return voting.NewValidationError(voting.CodeChoiceOutOfRange, "choice %d is not between 1 and %d", voteChoice, len(proposalChoices))
+	}
+
+	return nil
 }
 
 func IsValidChoice(voteChoice int, proposalChoices []string) bool {
-	return voteChoice > 0 && voteChoice <= len(proposalChoices)
+	return ValidateChoice(voteChoice, proposalChoices) == nil
+}
+
+func (v *SingleChoiceVoting) GetChoices() []string {
+	return v.Choices
+}
+
+func (v *SingleChoiceVoting) GetStrategies() []voting.Strategy {
+	return v.Strategies
+}
+
+func (v *SingleChoiceVoting) GetDecimals() int {
+	return v.Decimals
+}
+
+func (v *SingleChoiceVoting) GetVotes() []voting.Vote {
+	return voting.ToVotes(v.Votes)
+}
+
+func (v *SingleChoiceVoting) Deduplicate(policy voting.DedupPolicy) []voting.Vote {
+	votes, superseded := voting.Deduplicate(v.Votes, policy)
+	v.Votes = votes
+	return voting.ToVotes(superseded)
+}
+
+func (v *SingleChoiceVoting) IsValidVote(vote voting.Vote) bool {
+	return v.ValidateVote(vote) == nil
+}
+
+func (v *SingleChoiceVoting) ValidateVote(vote voting.Vote) error {
+	singleChoiceVote, ok := vote.(SingleChoiceVote)
+	if !ok {
+		return voting.NewValidationError(voting.CodeInvalidVoteType, "expected %T, got %T", SingleChoiceVote{}, vote)
+	}
+
+	return v.validateVote(singleChoiceVote)
+}
+
+func (v *SingleChoiceVoting) validateVote(vote SingleChoiceVote) error {
+	if err := voting.ValidateStrategies(v.Strategies); err != nil {
+		return err
+	}
+
+	if err := ValidateChoice(vote.Choice, v.Choices); err != nil {
+		return err
+	}
+
+	return voting.ValidateAmounts(vote.Balance, vote.Scores, vote.ExactBalance, vote.ExactScores, vote.BalanceUnits, vote.ScoresUnits, len(v.Strategies))
+}
+
+func (v *SingleChoiceVoting) power(vote SingleChoiceVote) (float64, []float64) {
+	balance, scores := voting.FloatAmounts(vote.Balance, vote.Scores, vote.ExactBalance, vote.ExactScores, vote.BalanceUnits, vote.ScoresUnits, v.Decimals)
+	return voting.EffectivePower(v.Strategies, balance, scores)
 }
 
 func (v *SingleChoiceVoting) GetValidVotes() []SingleChoiceVote {
-	return funk.Filter(v.Votes, func(vote SingleChoiceVote) bool {
-		return IsValidChoice(vote.Choice, v.Choices)
-	}).([]SingleChoiceVote)
+	return utils.Filter(v.Votes, func(vote SingleChoiceVote) bool {
+		return v.validateVote(vote) == nil
+	})
 }
 
 func (v *SingleChoiceVoting) GetScoresTotal() float64 {
-	return funk.Reduce(v.Votes, func(acc float64, vote SingleChoiceVote) float64 {
-		return acc + vote.Balance
-	}, float64(0)).(float64)
+	scoresTotal := utils.Accumulator{}
+	for _, vote := range v.GetValidVotes() {
+		balance, _ := v.power(vote)
+		scoresTotal.Add(balance)
+	}
+	return scoresTotal.Float64()
 }
 
 func (v *SingleChoiceVoting) GetScores() []float64 {
-	scores := []float64{}
-
-	for range v.Choices {
-		scores = append(scores, float64(0))
-	}
+	scores := make([]utils.Accumulator, len(v.Choices))
 
 	for _, vote := range v.Votes {
 		choice := vote.Choice
-		if IsValidChoice(choice, v.Choices) {
-			scores[choice-1] = scores[choice-1] + vote.Balance
+		if v.validateVote(vote) == nil {
+			balance, _ := v.power(vote)
+			scores[choice-1].Add(balance)
 		}
 	}
 
-	return scores
+	return utils.AccumulatorsToFloats(scores)
 }
 
 func (v *SingleChoiceVoting) GetScoresByStrategy() [][]float64 {
-	scoresByStrategy := [][]float64{}
-
-	for range v.Choices {
-		scores := []float64{}
-		for range v.Strategies {
-			scores = append(scores, float64(0))
-		}
-		scoresByStrategy = append(scoresByStrategy, scores)
-	}
+	scoresByStrategy := utils.NewAccumulatorMatrix(len(v.Choices), len(v.Strategies))
 
 	for _, vote := range v.Votes {
 		choice := vote.Choice
-		if IsValidChoice(choice, v.Choices) {
-			for idx, score := range vote.Scores {
-				scoresByStrategy[choice-1][idx] = scoresByStrategy[choice-1][idx] + score
+		if v.validateVote(vote) == nil {
+			_, scores := v.power(vote)
+			for idx, score := range scores {
+				scoresByStrategy[choice-1][idx].Add(score)
 			}
 		}
 	}
 
-	return scoresByStrategy
+	return utils.AccumulatorMatrixToFloats(scoresByStrategy)
 }
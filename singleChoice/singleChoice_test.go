@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/This-Is-Prince/votingSystemGo/utils"
+	"github.com/This-Is-Prince/votingSystemGo/voting"
 )
 
 func TestSingleChoiceVoting(t *testing.T) {
@@ -41,7 +42,7 @@ func TestSingleChoiceVoting(t *testing.T) {
 	singleChoiceVoting := SingleChoiceVoting{
 		Choices:    choices,
 		Votes:      votes,
-		Strategies: []interface{}{1, 2},
+		Strategies: []voting.Strategy{{Name: "erc20-balance-of"}, {Name: "delegation"}},
 	}
 
 	validVotes := singleChoiceVoting.GetValidVotes()
@@ -93,3 +94,30 @@ func TestSingleChoiceVoting(t *testing.T) {
 	}
 
 }
+
+func BenchmarkGetValidVotes(b *testing.B) {
+	singleChoiceVoting := SingleChoiceVoting{Choices: []string{"First", "Second", "Third"}, Strategies: []voting.Strategy{{Name: "erc20-balance-of"}}}
+	for i := 0; i < 100000; i++ {
+		singleChoiceVoting.Votes = append(singleChoiceVoting.Votes, SingleChoiceVote{Choice: i%4 + 1, Balance: 1, Scores: []float64{1}})
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		singleChoiceVoting.GetValidVotes()
+	}
+}
+
+func BenchmarkGetScores(b *testing.B) {
+	singleChoiceVoting := SingleChoiceVoting{Choices: []string{"First", "Second", "Third", "Fourth"}, Strategies: []voting.Strategy{{Name: "erc20-balance-of"}}}
+	for i := 0; i < 100000; i++ {
+		balance := float64(i%1000) / 7
+		singleChoiceVoting.Votes = append(singleChoiceVoting.Votes, SingleChoiceVote{Choice: i%4 + 1, Balance: balance, Scores: []float64{balance}})
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		singleChoiceVoting.GetScores()
+		singleChoiceVoting.GetScoresByStrategy()
+	}
+}
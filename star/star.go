@@ -0,0 +1,255 @@
+package star
+
+import (
+	"sort"
+
+	"github.com/This-Is-Prince/votingSystemGo/score"
+	"github.com/This-Is-Prince/votingSystemGo/utils"
+	"github.com/This-Is-Prince/votingSystemGo/voting"
+)
+
+// TieBreak names the rule that settled a tied runoff.
+type TieBreak string
+
+const (
+	// ScoreTieBreak gives a tied runoff to the finalist with the higher
+	// score-phase total.
+	ScoreTieBreak TieBreak = "score"
+	// IndexTieBreak gives a runoff still tied on score to the finalist
+	// listed first.
+	IndexTieBreak TieBreak = "index"
+)
+
+// STARVoting runs Score Then Automatic Runoff on score ballots. In the score
+// phase every rating counts times the ballot's balance and choices a ballot
+// leaves unrated count at Min. The two highest totals, ties going to the
+// choice listed first, meet in a runoff where each ballot gives its balance
+// to the finalist it rates higher. Min and Max default as in score voting.
+// Like score voting it counts float balances only, and the runoff needs
+// every ballot, so STAR proposals cannot be streamed.
+type STARVoting struct {
+	Choices    []string          `json:"choices"`
+	Votes      []score.ScoreVote `json:"votes"`
+	Strategies []voting.Strategy `json:"strategies"`
+	Min        float64           `json:"min"`
+	Max        float64           `json:"max"`
+}
+
+// Result holds both phases of a STAR count. Scores are the score-phase
+// totals and Finalists the 1-based choices in the runoff, highest score
+// first. Runoff is the balance preferring each finalist and NoPreference the
+// balance rating both the same. TieBreak is empty unless the runoff tied.
+// Winner is 0 when there are no valid votes.
+type Result struct {
+	Scores       []float64 `json:"scores"`
+	Finalists    []int     `json:"finalists"`
+	Runoff       []float64 `json:"runoff"`
+	NoPreference float64   `json:"noPreference"`
+	Winner       int       `json:"winner"`
+	TieBreak     TieBreak  `json:"tieBreak,omitempty"`
+}
+
+var (
+	_ voting.VotingSystem = (*STARVoting)(nil)
+	_ voting.TieSettler   = (*STARVoting)(nil)
+)
+
+// scoreVoting returns the score phase of the count.
+func (v *STARVoting) scoreVoting() *score.ScoreVoting {
+	return &score.ScoreVoting{
+		Choices:     v.Choices,
+		Votes:       v.Votes,
+		Strategies:  v.Strategies,
+		Min:         v.Min,
+		Max:         v.Max,
+		Unrated:     score.Minimum,
+		Aggregation: score.Sum,
+	}
+}
+
+func (v *STARVoting) GetChoices() []string {
+	return v.Choices
+}
+
+func (v *STARVoting) GetStrategies() []voting.Strategy {
+	return v.Strategies
+}
+
+func (v *STARVoting) GetVotes() []voting.Vote {
+	return voting.ToVotes(v.Votes)
+}
+
+func (v *STARVoting) Deduplicate(policy voting.DedupPolicy) []voting.Vote {
+	votes, superseded := voting.Deduplicate(v.Votes, policy)
+	v.Votes = votes
+	return voting.ToVotes(superseded)
+}
+
+func (v *STARVoting) IsValidVote(vote voting.Vote) bool {
+	return v.ValidateVote(vote) == nil
+}
+
+func (v *STARVoting) ValidateVote(vote voting.Vote) error {
+	return v.scoreVoting().ValidateVote(vote)
+}
+
+func (v *STARVoting) power(vote score.ScoreVote) (float64, []float64) {
+	return voting.EffectivePower(v.Strategies, vote.Balance, vote.Scores)
+}
+
+func (v *STARVoting) GetValidVotes() []score.ScoreVote {
+	return v.scoreVoting().GetValidVotes()
+}
+
+func (v *STARVoting) GetScoresTotal() float64 {
+	return v.scoreVoting().GetScoresTotal()
+}
+
+// Finalists returns the 1-based choices with the two highest scores,
+// highest first. Choices whose scores are equal to the highest one left
+// are taken in the order they are listed.
+func Finalists(scores []float64) []int {
+	remaining := []int{}
+	for idx := range scores {
+		remaining = append(remaining, idx+1)
+	}
+
+	sort.SliceStable(remaining, func(a, b int) bool {
+		return scores[remaining[a]-1] > scores[remaining[b]-1]
+	})
+
+	finalists := []int{}
+	for len(finalists) < 2 && len(remaining) > 0 {
+		highest := scores[remaining[0]-1]
+		tied, rest := []int{}, []int{}
+		for _, choice := range remaining {
+			if utils.FloatEqual(scores[choice-1], highest) {
+				tied = append(tied, choice)
+			} else {
+				rest = append(rest, choice)
+			}
+		}
+		sort.Ints(tied)
+
+		taken := min(len(tied), 2-len(finalists))
+		finalists = append(finalists, tied[:taken]...)
+		remaining = append(tied[taken:], rest...)
+	}
+	return finalists
+}
+
+// runoff returns the weight preferring each finalist and the weight rating
+// them the same. A lone finalist takes every ballot.
+func (v *STARVoting) runoff(finalists []int, weight func(vote score.ScoreVote) float64) ([]float64, float64) {
+	scoreVoting := v.scoreVoting()
+	support := make([]utils.Accumulator, len(finalists))
+	noPreference := utils.Accumulator{}
+
+	for _, vote := range v.GetValidVotes() {
+		w := weight(vote)
+		switch len(finalists) {
+		case 0:
+		case 1:
+			support[0].Add(w)
+		default:
+			first, _ := scoreVoting.Rating(vote, finalists[0])
+			second, _ := scoreVoting.Rating(vote, finalists[1])
+			switch {
+			case first > second:
+				support[0].Add(w)
+			case second > first:
+				support[1].Add(w)
+			default:
+				noPreference.Add(w)
+			}
+		}
+	}
+
+	return utils.AccumulatorsToFloats(support), noPreference.Float64()
+}
+
+func (v *STARVoting) GetResult() Result {
+	scores := v.scoreVoting().GetScores()
+	finalists := Finalists(scores)
+	runoff, noPreference := v.runoff(finalists, func(vote score.ScoreVote) float64 {
+		balance, _ := v.power(vote)
+		return balance
+	})
+
+	result := Result{
+		Scores:       scores,
+		Finalists:    finalists,
+		Runoff:       runoff,
+		NoPreference: noPreference,
+	}
+
+	if len(v.GetValidVotes()) == 0 || len(finalists) == 0 {
+		return result
+	}
+
+	result.Winner = finalists[0]
+	if len(finalists) == 1 {
+		return result
+	}
+
+	first, second := finalists[0], finalists[1]
+	switch {
+	case !utils.FloatEqual(runoff[0], runoff[1]):
+		if runoff[1] > runoff[0] {
+			result.Winner = second
+		}
+	case !utils.FloatEqual(scores[first-1], scores[second-1]):
+		// Finalists are ordered by score, so the first one has more.
+		result.TieBreak = ScoreTieBreak
+	default:
+		result.Winner = min(first, second)
+		result.TieBreak = IndexTieBreak
+	}
+
+	return result
+}
+
+func (v *STARVoting) GetWinner() int {
+	return v.GetResult().Winner
+}
+
+// SettleTie returns the winner of a runoff its scores leave tied, so
+// voting.NewResult agrees with GetResult.
+func (v *STARVoting) SettleTie() (int, string) {
+	result := v.GetResult()
+	return result.Winner, string(result.TieBreak)
+}
+
+// GetScores returns the runoff: the balance preferring each finalist, and
+// 0 for every other choice. GetResult has the score phase; a tied runoff is
+// settled by SettleTie.
+func (v *STARVoting) GetScores() []float64 {
+	result := v.GetResult()
+	scores := make([]float64, len(v.Choices))
+	for idx, finalist := range result.Finalists {
+		scores[finalist-1] = result.Runoff[idx]
+	}
+	return scores
+}
+
+// GetScoresByStrategy splits the runoff by strategy, each ballot giving its
+// score for the strategy to the finalist it prefers.
+func (v *STARVoting) GetScoresByStrategy() [][]float64 {
+	scoresByStrategy := [][]float64{}
+	for range v.Choices {
+		scoresByStrategy = append(scoresByStrategy, make([]float64, len(v.Strategies)))
+	}
+
+	finalists := Finalists(v.scoreVoting().GetScores())
+	for idx := range v.Strategies {
+		runoff, _ := v.runoff(finalists, func(vote score.ScoreVote) float64 {
+			_, scores := v.power(vote)
+			return scores[idx]
+		})
+		for fIdx, finalist := range finalists {
+			scoresByStrategy[finalist-1][idx] = runoff[fIdx]
+		}
+	}
+
+	return scoresByStrategy
+}
@@ -0,0 +1,134 @@
+package star
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/This-Is-Prince/votingSystemGo/score"
+	"github.com/This-Is-Prince/votingSystemGo/utils"
+	"github.com/This-Is-Prince/votingSystemGo/voting"
+)
+
+func TestSTARVoting(t *testing.T) {
+	choices := []string{"First", "Second", "Third"}
+	votes := []score.ScoreVote{
+		{
+			Choice:  score.ScoreChoice{"1": 5, "2": 4},
+			Balance: float64(3),
+			Scores:  []float64{float64(3), float64(0)},
+		},
+		{
+			Choice:  score.ScoreChoice{"2": 5, "3": 5},
+			Balance: float64(2),
+			Scores:  []float64{float64(1), float64(1)},
+		},
+		{
+			Choice:  score.ScoreChoice{"1": 0, "2": 3, "3": 5},
+			Balance: float64(2),
+			Scores:  []float64{float64(0), float64(2)},
+		},
+		{
+			Choice:  score.ScoreChoice{"1": 6},
+			Balance: float64(1),
+			Scores:  []float64{float64(1), float64(0)},
+		},
+	}
+	starVoting := STARVoting{
+		Choices:    choices,
+		Votes:      votes,
+		Strategies: []voting.Strategy{{Name: "erc20-balance-of"}, {Name: "delegation"}},
+	}
+
+	validVotes := starVoting.GetValidVotes()
+	if len(validVotes) != len(votes)-1 {
+		t.Errorf("Expected %d valid votes, got %d", len(votes)-1, len(validVotes))
+	}
+
+	expectedScoresTotal := float64(7)
+	scoresTotal := starVoting.GetScoresTotal()
+	if !utils.FloatEqual(scoresTotal, expectedScoresTotal) {
+		t.Errorf("Expected scores total to be %f, got %f", expectedScoresTotal, scoresTotal)
+	}
+
+	// The first ballot leaves the third choice unrated, which counts as 0.
+	// The second ballot rates both finalists 5, so it has no preference.
+	expectedResult := Result{
+		Scores:       []float64{15, 28, 20},
+		Finalists:    []int{2, 3},
+		Runoff:       []float64{3, 2},
+		NoPreference: 2,
+		Winner:       2,
+	}
+	if result := starVoting.GetResult(); !reflect.DeepEqual(result, expectedResult) {
+		t.Errorf("Expected result %+v, got %+v", expectedResult, result)
+	}
+
+	expectedScores := []float64{0, 3, 2}
+	if scores := starVoting.GetScores(); !reflect.DeepEqual(scores, expectedScores) {
+		t.Errorf("Expected scores %v, got %v", expectedScores, scores)
+	}
+
+	expectedScoresByStrategy := [][]float64{{0, 0}, {3, 0}, {0, 2}}
+	if scoresByStrategy := starVoting.GetScoresByStrategy(); !reflect.DeepEqual(scoresByStrategy, expectedScoresByStrategy) {
+		t.Errorf("Expected scores by strategy %v, got %v", expectedScoresByStrategy, scoresByStrategy)
+	}
+
+	// With less balance behind the first ballot the runoff ties at 2, and
+	// the second choice wins on its higher score.
+	starVoting.Votes[0].Balance = 2
+	starVoting.Votes[0].Scores = []float64{float64(2), float64(0)}
+	result := starVoting.GetResult()
+	if result.Winner != 2 || result.TieBreak != ScoreTieBreak {
+		t.Errorf("Expected choice 2 to win by %q, got %d by %q", ScoreTieBreak, result.Winner, result.TieBreak)
+	}
+
+	// Tied on both runoff and score, the choice listed first wins.
+	tiedVoting := STARVoting{
+		Choices: []string{"First", "Second"},
+		Votes: []score.ScoreVote{
+			{Choice: score.ScoreChoice{"2": 5}, Balance: float64(1)},
+			{Choice: score.ScoreChoice{"1": 5}, Balance: float64(1)},
+		},
+	}
+	result = tiedVoting.GetResult()
+	if result.Winner != 1 || result.TieBreak != IndexTieBreak {
+		t.Errorf("Expected choice 1 to win by %q, got %d by %q", IndexTieBreak, result.Winner, result.TieBreak)
+	}
+
+	// The generic result reports the tied runoff but the same winner, and
+	// a tie-breaker does not override it.
+	for _, generic := range []voting.Result{voting.NewResult(&tiedVoting), voting.NewResultWithTieBreaker(&tiedVoting, voting.LowestIndex{})} {
+		if !generic.Tie || generic.Winner != 1 || generic.TieBreak != string(IndexTieBreak) {
+			t.Errorf("Expected a tie won by choice 1 by %q, got %+v", IndexTieBreak, generic)
+		}
+	}
+
+	empty := STARVoting{Choices: choices}
+	if winner := empty.GetWinner(); winner != 0 {
+		t.Errorf("Expected no winner without votes, got %d", winner)
+	}
+
+	finalists := []struct {
+		scores   []float64
+		expected []int
+	}{
+		{[]float64{1, 3, 3}, []int{2, 3}},
+		{[]float64{2, 1, 2}, []int{1, 3}},
+		{[]float64{4}, []int{1}},
+	}
+	for _, test := range finalists {
+		if got := Finalists(test.scores); !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("Expected finalists %v for scores %v, got %v", test.expected, test.scores, got)
+		}
+	}
+
+	// Near-equal scores are not chained together: only those within the
+	// tolerance of the highest tie with it, whatever the order.
+	low, middle, high := 10.0, 10+6e-8, 10+1.2e-7
+	for _, order := range [][]float64{{low, middle, high}, {high, middle, low}, {middle, low, high}, {high, low, middle}, {low, high, middle}, {middle, high, low}} {
+		got := Finalists(order)
+		if len(got) != 2 || order[got[0]-1] == low || order[got[1]-1] == low {
+			t.Errorf("Expected the two highest scores of %v to be finalists, got %v", order, got)
+		}
+	}
+}
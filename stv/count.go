@@ -0,0 +1,202 @@
+package stv
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/This-Is-Prince/votingSystemGo/utils"
+)
+
+const (
+	continuing = iota
+	elected
+	eliminated
+)
+
+// ballot is a valid vote during the count. weight is the share of the
+// ballot still moving with its preferences; the rest has been kept by the
+// choices it helped elect.
+type ballot struct {
+	choice  []int
+	balance *big.Rat
+	scores  []*big.Rat
+	weight  *big.Rat
+}
+
+// GetRounds runs the count and returns every round, the last one being the
+// round in which the final seat was filled. Once every ballot is exhausted,
+// the lowest choices are still eliminated until the rest fit the open
+// seats. A proposal without any balance elects nobody.
+func (v *STVVoting) GetRounds() []Round {
+	rounds, _ := v.count()
+	return rounds
+}
+
+// count returns the rounds and the final round's tallies by strategy.
+func (v *STVVoting) count() ([]Round, [][]*big.Rat) {
+	n := len(v.Choices)
+	seats := min(v.GetSeats(), n)
+	quota := v.GetQuota()
+
+	ballots := []*ballot{}
+	for _, vote := range v.GetValidVotes() {
+		balance, scores := v.power(vote)
+		ballots = append(ballots, &ballot{choice: vote.Choice, balance: balance, scores: scores, weight: big.NewRat(1, 1)})
+	}
+
+	state := make([]int, n)
+	kept := utils.NewRats(n)
+	keptByStrategy := utils.NewRatMatrix(n, len(v.Strategies))
+	electedCount := 0
+	rounds := []Round{}
+
+	for {
+		tallies := utils.NewRats(n)
+		talliesByStrategy := utils.NewRatMatrix(n, len(v.Strategies))
+		for idx := range state {
+			if state[idx] == elected {
+				tallies[idx].Set(kept[idx])
+				for sIdx := range v.Strategies {
+					talliesByStrategy[idx][sIdx].Set(keptByStrategy[idx][sIdx])
+				}
+			}
+		}
+
+		round := Round{
+			Round:     len(rounds) + 1,
+			Tallies:   tallies,
+			Elected:   []int{},
+			Transfers: []Transfer{},
+			Exhausted: new(big.Rat),
+		}
+
+		tops := make([]int, len(ballots))
+		for bIdx, b := range ballots {
+			value := new(big.Rat).Mul(b.weight, b.balance)
+			tops[bIdx] = topContinuing(b.choice, state)
+			if tops[bIdx] == 0 {
+				round.Exhausted.Add(round.Exhausted, value)
+				continue
+			}
+			choice := tops[bIdx]
+			tallies[choice-1].Add(tallies[choice-1], value)
+			for sIdx, score := range b.scores {
+				talliesByStrategy[choice-1][sIdx].Add(talliesByStrategy[choice-1][sIdx], new(big.Rat).Mul(b.weight, score))
+			}
+		}
+
+		remaining := []int{}
+		for idx := range state {
+			if state[idx] == continuing {
+				remaining = append(remaining, idx+1)
+			}
+		}
+
+		if electedCount == seats || len(remaining) == 0 || quota.Sign() == 0 {
+			rounds = append(rounds, round)
+			return rounds, talliesByStrategy
+		}
+
+		byTally := func(choices []int) {
+			sort.SliceStable(choices, func(a, b int) bool {
+				return tallies[choices[a]-1].Cmp(tallies[choices[b]-1]) > 0
+			})
+		}
+
+		// Once the choices left fit the open seats they are all elected.
+		if len(remaining) <= seats-electedCount {
+			byTally(remaining)
+			for _, choice := range remaining {
+				state[choice-1] = elected
+				electedCount++
+			}
+			round.Elected = remaining
+			rounds = append(rounds, round)
+			return rounds, talliesByStrategy
+		}
+
+		reached := []int{}
+		for _, choice := range remaining {
+			if tallies[choice-1].Cmp(quota) > 0 {
+				reached = append(reached, choice)
+			}
+		}
+		byTally(reached)
+
+		if len(reached) > 0 {
+			for _, choice := range reached {
+				state[choice-1] = elected
+				electedCount++
+
+				tally := tallies[choice-1]
+				surplus := new(big.Rat).Sub(tally, quota)
+				value := new(big.Rat).Quo(surplus, tally)
+				keptShare := new(big.Rat).Sub(big.NewRat(1, 1), value)
+				for bIdx, b := range ballots {
+					if tops[bIdx] != choice {
+						continue
+					}
+					keptWeight := new(big.Rat).Mul(b.weight, keptShare)
+					kept[choice-1].Add(kept[choice-1], new(big.Rat).Mul(keptWeight, b.balance))
+					for sIdx, score := range b.scores {
+						keptByStrategy[choice-1][sIdx].Add(keptByStrategy[choice-1][sIdx], new(big.Rat).Mul(keptWeight, score))
+					}
+					b.weight.Mul(b.weight, value)
+				}
+
+				round.Transfers = append(round.Transfers, Transfer{Choice: choice, Surplus: surplus, Value: value})
+			}
+			round.Elected = reached
+			rounds = append(rounds, round)
+			continue
+		}
+
+		loser := choiceToEliminate(tallies, rounds, state)
+		state[loser-1] = eliminated
+		round.Eliminated = loser
+		rounds = append(rounds, round)
+	}
+}
+
+// topContinuing returns the highest-ranked choice still in the count, or 0.
+func topContinuing(choices []int, state []int) int {
+	for _, choice := range choices {
+		if state[choice-1] == continuing {
+			return choice
+		}
+	}
+	return 0
+}
+
+// choiceToEliminate returns the continuing choice with the lowest tally.
+// A tie is broken by the tallies of earlier rounds, latest first, and then
+// by eliminating the last-listed choice, as ranked-choice voting does.
+func choiceToEliminate(tallies []*big.Rat, previousRounds []Round, state []int) int {
+	tied := []int{}
+	for idx, tally := range tallies {
+		if state[idx] != continuing {
+			continue
+		}
+		switch {
+		case len(tied) == 0 || tally.Cmp(tallies[tied[0]-1]) < 0:
+			tied = []int{idx + 1}
+		case tally.Cmp(tallies[tied[0]-1]) == 0:
+			tied = append(tied, idx+1)
+		}
+	}
+
+	for r := len(previousRounds) - 1; r >= 0 && len(tied) > 1; r-- {
+		previousTallies := previousRounds[r].Tallies
+		lowest := previousTallies[tied[0]-1]
+		for _, choice := range tied {
+			if previousTallies[choice-1].Cmp(lowest) < 0 {
+				lowest = previousTallies[choice-1]
+			}
+		}
+		tied = utils.Filter(tied, func(choice int) bool {
+			return previousTallies[choice-1].Cmp(lowest) == 0
+		})
+	}
+
+	return tied[len(tied)-1]
+}
@@ -0,0 +1,212 @@
+package stv
+
+import (
+	"math/big"
+
+	"github.com/This-Is-Prince/votingSystemGo/utils"
+	"github.com/This-Is-Prince/votingSystemGo/voting"
+)
+
+type STVVote struct {
+	Choice       []int      `json:"choice"`
+	Balance      float64    `json:"balance"`
+	Scores       []float64  `json:"scores"`
+	ExactBalance *big.Rat   `json:"exactBalance,omitempty"`
+	ExactScores  []*big.Rat `json:"exactScores,omitempty"`
+	Voter        string     `json:"voter,omitempty"`
+	Created      int64      `json:"created,omitempty"`
+}
+
+// STVVoting elects Seats choices by the Single Transferable Vote. Ballots
+// are weighted by balance and counted in exact rational arithmetic. The
+// quota is the exact Droop quota, total / (seats + 1), and a choice is
+// elected once its tally exceeds it. Surpluses are transferred by the
+// weighted inclusive Gregory method: every ballot of an elected choice moves
+// on at its current weight times surplus / tally. Seats defaults to 1.
+type STVVoting struct {
+	Choices    []string          `json:"choices"`
+	Votes      []STVVote         `json:"votes"`
+	Strategies []voting.Strategy `json:"strategies"`
+	Seats      int               `json:"seats"`
+}
+
+// Round is one STV counting round. Tallies holds each choice's votes at the
+// start of the round; elected choices keep the quota once their surplus is
+// gone. Elected lists the choices elected in the round, Transfers their
+// surplus transfers, and Eliminated the choice excluded, or 0. Exhausted is
+// the weight of ballots with no continuing preference left, so far.
+type Round struct {
+	Round      int        `json:"round"`
+	Tallies    []*big.Rat `json:"tallies"`
+	Elected    []int      `json:"elected"`
+	Transfers  []Transfer `json:"transfers"`
+	Eliminated int        `json:"eliminated"`
+	Exhausted  *big.Rat   `json:"exhausted"`
+}
+
+// Transfer is the surplus of an elected choice and the transfer value its
+// ballots moved on at.
+type Transfer struct {
+	Choice  int      `json:"choice"`
+	Surplus *big.Rat `json:"surplus"`
+	Value   *big.Rat `json:"value"`
+}
+
+var _ voting.VotingSystem = (*STVVoting)(nil)
+
+func (v STVVote) GetChoice() interface{} {
+	return v.Choice
+}
+
+func (v STVVote) GetBalance() float64 {
+	return v.Balance
+}
+
+func (v STVVote) GetScores() []float64 {
+	return v.Scores
+}
+
+func (v STVVote) GetVoter() string {
+	return v.Voter
+}
+
+func (v STVVote) GetCreated() int64 {
+	return v.Created
+}
+
+func (v STVVote) Supports(choice int) bool {
+	return len(v.Choice) > 0 && v.Choice[0] == choice
+}
+
+func (v STVVote) GetExactBalance() *big.Rat {
+	return utils.ExactValue(nil, v.ExactBalance, v.Balance, 0)
+}
+
+func (v STVVote) GetExactScores() []*big.Rat {
+	return utils.ExactValues(nil, v.ExactScores, v.Scores, 0)
+}
+
+func ValidateChoice(voteChoice []int, proposalChoices []string) error {
+	if len(voteChoice) == 0 {
+		return voting.NewValidationError(voting.CodeEmptyChoice, "no choice is ranked")
+	}
+
+	voteChoiceSet := make(map[int]struct{})
+	for _, c := range voteChoice {
+		if c <= 0 || c > len(proposalChoices) {
+			return voting.NewValidationError(voting.CodeChoiceOutOfRange, "choice %d is not between 1 and %d", c, len(proposalChoices))
+		}
+		if _, ok := voteChoiceSet[c]; ok {
+			return voting.NewValidationError(voting.CodeDuplicateChoice, "choice %d is ranked more than once", c)
+		}
+		voteChoiceSet[c] = struct{}{}
+	}
+
+	return nil
+}
+
+func IsValidChoice(voteChoice []int, proposalChoices []string) bool {
+	return ValidateChoice(voteChoice, proposalChoices) == nil
+}
+
+func (v *STVVoting) GetChoices() []string {
+	return v.Choices
+}
+
+func (v *STVVoting) GetStrategies() []voting.Strategy {
+	return v.Strategies
+}
+
+func (v *STVVoting) GetVotes() []voting.Vote {
+	return voting.ToVotes(v.Votes)
+}
+
+func (v *STVVoting) Deduplicate(policy voting.DedupPolicy) []voting.Vote {
+	votes, superseded := voting.Deduplicate(v.Votes, policy)
+	v.Votes = votes
+	return voting.ToVotes(superseded)
+}
+
+func (v *STVVoting) IsValidVote(vote voting.Vote) bool {
+	return v.ValidateVote(vote) == nil
+}
+
+func (v *STVVoting) ValidateVote(vote voting.Vote) error {
+	stvVote, ok := vote.(STVVote)
+	if !ok {
+		return voting.NewValidationError(voting.CodeInvalidVoteType, "expected %T, got %T", STVVote{}, vote)
+	}
+
+	return v.validateVote(stvVote)
+}
+
+func (v *STVVoting) validateVote(vote STVVote) error {
+	if err := voting.ValidateStrategies(v.Strategies); err != nil {
+		return err
+	}
+
+	if err := ValidateChoice(vote.Choice, v.Choices); err != nil {
+		return err
+	}
+
+	return voting.ValidateAmounts(vote.Balance, vote.Scores, vote.ExactBalance, vote.ExactScores, nil, nil, len(v.Strategies))
+}
+
+func (v *STVVoting) power(vote STVVote) (*big.Rat, []*big.Rat) {
+	return voting.EffectivePowerExact(v.Strategies, vote.GetExactBalance(), vote.GetExactScores())
+}
+
+func (v *STVVoting) GetValidVotes() []STVVote {
+	return utils.Filter(v.Votes, func(vote STVVote) bool {
+		return v.validateVote(vote) == nil
+	})
+}
+
+// GetSeats returns the number of seats to fill.
+func (v *STVVoting) GetSeats() int {
+	if v.Seats <= 0 {
+		return 1
+	}
+	return v.Seats
+}
+
+func (v *STVVoting) GetScoresTotalExact() *big.Rat {
+	scoresTotal := new(big.Rat)
+	for _, vote := range v.GetValidVotes() {
+		balance, _ := v.power(vote)
+		scoresTotal.Add(scoresTotal, balance)
+	}
+	return scoresTotal
+}
+
+func (v *STVVoting) GetScoresTotal() float64 {
+	scoresTotal, _ := v.GetScoresTotalExact().Float64()
+	return scoresTotal
+}
+
+// GetQuota returns the exact Droop quota, total / (seats + 1).
+func (v *STVVoting) GetQuota() *big.Rat {
+	return new(big.Rat).Quo(v.GetScoresTotalExact(), big.NewRat(int64(v.GetSeats()+1), 1))
+}
+
+// GetElected returns the elected choices in the order they were elected.
+func (v *STVVoting) GetElected() []int {
+	elected := []int{}
+	for _, round := range v.GetRounds() {
+		elected = append(elected, round.Elected...)
+	}
+	return elected
+}
+
+// GetScores returns the tallies of the final round.
+func (v *STVVoting) GetScores() []float64 {
+	rounds := v.GetRounds()
+	return utils.RatsToFloats(rounds[len(rounds)-1].Tallies)
+}
+
+// GetScoresByStrategy splits the final round's tallies by strategy, each
+// ballot counting its scores at the weight it counted its balance.
+func (v *STVVoting) GetScoresByStrategy() [][]float64 {
+	_, scoresByStrategy := v.count()
+	return utils.RatMatrixToFloats(scoresByStrategy)
+}
@@ -0,0 +1,211 @@
+package stv
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/This-Is-Prince/votingSystemGo/utils"
+	"github.com/This-Is-Prince/votingSystemGo/voting"
+)
+
+func rat(a, b int64) *big.Rat {
+	return big.NewRat(a, b)
+}
+
+func ratsEqual(a, b []*big.Rat) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for idx := range a {
+		if a[idx].Cmp(b[idx]) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSTVVoting(t *testing.T) {
+	choices := []string{"First", "Second", "Third", "Fourth"}
+	votes := []STVVote{
+		{
+			Choice:  []int{1, 2},
+			Balance: float64(5.5),
+			Scores:  []float64{float64(5.5), float64(0)},
+		},
+		{
+			Choice:  []int{1, 3},
+			Balance: float64(2.5),
+			Scores:  []float64{float64(0.5), float64(2)},
+		},
+		{
+			Choice:  []int{2},
+			Balance: float64(1.5),
+			Scores:  []float64{float64(1.5), float64(0)},
+		},
+		{
+			Choice:  []int{3, 2},
+			Balance: float64(2),
+			Scores:  []float64{float64(2), float64(0)},
+		},
+		{
+			Choice:  []int{4},
+			Balance: float64(1.5),
+			Scores:  []float64{float64(1.5), float64(0)},
+		},
+		{
+			Choice:  []int{5},
+			Balance: float64(1),
+			Scores:  []float64{float64(1), float64(0)},
+		},
+	}
+	stvVoting := STVVoting{
+		Choices:    choices,
+		Votes:      votes,
+		Strategies: []voting.Strategy{{Name: "erc20-balance-of"}, {Name: "delegation"}},
+		Seats:      2,
+	}
+
+	validVotes := stvVoting.GetValidVotes()
+	if len(validVotes) != len(votes)-1 {
+		t.Errorf("Expected %d valid votes, got %d", len(votes)-1, len(validVotes))
+	}
+
+	expectedScoresTotal := float64(13)
+	scoresTotal := stvVoting.GetScoresTotal()
+	if !utils.FloatEqual(scoresTotal, expectedScoresTotal) {
+		t.Errorf("Expected scores total to be %f, got %f", expectedScoresTotal, scoresTotal)
+	}
+
+	if quota := stvVoting.GetQuota(); quota.Cmp(rat(13, 3)) != 0 {
+		t.Errorf("Expected quota %v, got %v", rat(13, 3), quota)
+	}
+
+	// The first choice is elected with 8 and passes on 11/24 of each of its
+	// ballots. Nobody else reaches the quota, so the fourth and then the
+	// third choice are eliminated, and the second choice takes the last
+	// seat.
+	rounds := stvVoting.GetRounds()
+	expectedTallies := [][]*big.Rat{
+		{rat(8, 1), rat(3, 2), rat(2, 1), rat(3, 2)},
+		{rat(13, 3), rat(193, 48), rat(151, 48), rat(3, 2)},
+		{rat(13, 3), rat(193, 48), rat(151, 48), rat(0, 1)},
+		{rat(13, 3), rat(289, 48), rat(0, 1), rat(0, 1)},
+	}
+	expectedExhausted := []*big.Rat{rat(0, 1), rat(0, 1), rat(3, 2), rat(127, 48)}
+	expectedEliminated := []int{0, 4, 3, 0}
+	if len(rounds) != len(expectedTallies) {
+		t.Fatalf("Expected %d rounds, got %d", len(expectedTallies), len(rounds))
+	}
+	for idx, round := range rounds {
+		if round.Round != idx+1 {
+			t.Errorf("Expected round %d, got %d", idx+1, round.Round)
+		}
+		if !ratsEqual(round.Tallies, expectedTallies[idx]) {
+			t.Errorf("Expected round %d tallies %v, got %v", idx+1, expectedTallies[idx], round.Tallies)
+		}
+		if round.Exhausted.Cmp(expectedExhausted[idx]) != 0 {
+			t.Errorf("Expected round %d exhausted %v, got %v", idx+1, expectedExhausted[idx], round.Exhausted)
+		}
+		if round.Eliminated != expectedEliminated[idx] {
+			t.Errorf("Expected round %d to eliminate %d, got %d", idx+1, expectedEliminated[idx], round.Eliminated)
+		}
+	}
+
+	transfers := rounds[0].Transfers
+	if len(transfers) != 1 || transfers[0].Choice != 1 || transfers[0].Surplus.Cmp(rat(11, 3)) != 0 || transfers[0].Value.Cmp(rat(11, 24)) != 0 {
+		t.Errorf("Expected the first choice to transfer 11/3 at 11/24, got %v", transfers)
+	}
+
+	if elected := stvVoting.GetElected(); !reflect.DeepEqual(elected, []int{1, 2}) {
+		t.Errorf("Expected choices %v to be elected, got %v", []int{1, 2}, elected)
+	}
+
+	expectedScores := []float64{13.0 / 3, 289.0 / 48, 0, 0}
+	scores := stvVoting.GetScores()
+	for idx, score := range scores {
+		if !utils.FloatEqual(score, expectedScores[idx]) {
+			t.Errorf("Expected score %f for choice %d, got %f", expectedScores[idx], idx+1, score)
+		}
+	}
+
+	// The first choice keeps 13/24 of both its ballots, the second choice
+	// the rest of the first ballot and the whole of two others.
+	expectedScoresByStrategy := [][]float64{{13.0 / 4, 13.0 / 12}, {289.0 / 48, 0}, {0, 0}, {0, 0}}
+	scoresByStrategy := stvVoting.GetScoresByStrategy()
+	for idx, choiceScores := range scoresByStrategy {
+		for sIdx, score := range choiceScores {
+			if !utils.FloatEqual(score, expectedScoresByStrategy[idx][sIdx]) {
+				t.Errorf("Expected score %f for choice %d and strategy %d, got %f", expectedScoresByStrategy[idx][sIdx], idx+1, sIdx+1, score)
+			}
+		}
+	}
+
+	// Tenths are not exact as floats, so exact balances keep the quota
+	// exact.
+	exactVoting := STVVoting{
+		Choices: []string{"First", "Second"},
+		Votes: []STVVote{
+			{Choice: []int{1}, ExactBalance: rat(1, 10), ExactScores: []*big.Rat{rat(1, 10)}},
+			{Choice: []int{2}, ExactBalance: rat(2, 10), ExactScores: []*big.Rat{rat(2, 10)}},
+		},
+		Strategies: []voting.Strategy{{Name: "erc20-balance-of"}},
+	}
+	if quota := exactVoting.GetQuota(); quota.Cmp(rat(3, 20)) != 0 {
+		t.Errorf("Expected quota %v, got %v", rat(3, 20), quota)
+	}
+	if elected := exactVoting.GetElected(); !reflect.DeepEqual(elected, []int{2}) {
+		t.Errorf("Expected choices %v to be elected, got %v", []int{2}, elected)
+	}
+}
+
+func TestSTVElimination(t *testing.T) {
+	// The second and third choices tie for last with no earlier round to
+	// break it, so the last-listed choice goes first.
+	stvVoting := STVVoting{
+		Choices: []string{"First", "Second", "Third"},
+		Votes: []STVVote{
+			{Choice: []int{1}, Balance: float64(2), Scores: []float64{float64(2)}},
+			{Choice: []int{2, 1}, Balance: float64(1), Scores: []float64{float64(1)}},
+			{Choice: []int{3, 2}, Balance: float64(1), Scores: []float64{float64(1)}},
+		},
+		Strategies: []voting.Strategy{{Name: "erc20-balance-of"}},
+	}
+
+	rounds := stvVoting.GetRounds()
+	eliminated := []int{}
+	for _, round := range rounds {
+		if round.Eliminated != 0 {
+			eliminated = append(eliminated, round.Eliminated)
+		}
+	}
+	// The third choice's ballot then lifts the second choice level with
+	// the first at 2, and the first round breaks that tie against it.
+	if !reflect.DeepEqual(eliminated, []int{3, 2}) {
+		t.Errorf("Expected choices %v to be eliminated, got %v", []int{3, 2}, eliminated)
+	}
+
+	if elected := stvVoting.GetElected(); !reflect.DeepEqual(elected, []int{1}) {
+		t.Errorf("Expected choices %v to be elected, got %v", []int{1}, elected)
+	}
+
+	// Every ballot is exhausted once the first choice is elected, and the
+	// last seat goes to whichever choice survives elimination.
+	exhausted := STVVoting{
+		Choices: []string{"First", "Second", "Third"},
+		Votes: []STVVote{
+			{Choice: []int{1}, Balance: float64(3), Scores: []float64{float64(3)}},
+			{Choice: []int{1}, Balance: float64(1), Scores: []float64{float64(1)}},
+		},
+		Strategies: []voting.Strategy{{Name: "erc20-balance-of"}},
+		Seats:      2,
+	}
+	if elected := exhausted.GetElected(); !reflect.DeepEqual(elected, []int{1, 2}) {
+		t.Errorf("Expected choices %v to be elected, got %v", []int{1, 2}, elected)
+	}
+
+	empty := STVVoting{Choices: []string{"First", "Second"}, Seats: 1}
+	if elected := empty.GetElected(); len(elected) != 0 {
+		t.Errorf("Expected no choice elected without votes, got %v", elected)
+	}
+}
@@ -2,14 +2,63 @@ package utils
 
 import (
 	"math"
-
-	"github.com/thoas/go-funk"
+	"math/big"
+	"sort"
+	"strconv"
 )
 
+func Filter[T any](items []T, keep func(T) bool) []T {
+	filtered := []T{}
+	for _, item := range items {
+		if keep(item) {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered
+}
+
+func Map[T any, U any](items []T, mapper func(T) U) []U {
+	mapped := make([]U, 0, len(items))
+	for _, item := range items {
+		mapped = append(mapped, mapper(item))
+	}
+	return mapped
+}
+
+func Reduce[T any, A any](items []T, reducer func(acc A, item T) A, initial A) A {
+	acc := initial
+	for _, item := range items {
+		acc = reducer(acc, item)
+	}
+	return acc
+}
+
+func Flatten[T any](items [][]T) []T {
+	size := 0
+	for _, inner := range items {
+		size += len(inner)
+	}
+
+	flattened := make([]T, 0, size)
+	for _, inner := range items {
+		flattened = append(flattened, inner...)
+	}
+	return flattened
+}
+
+// Sum adds values left to right, so it rounds exactly like a plain loop.
+func Sum[T ~int | ~int64 | ~float64](values []T) T {
+	var sum T
+	for _, value := range values {
+		sum += value
+	}
+	return sum
+}
+
 func CalcReducedQuadraticScores(scoresTotal float64, percentages []float64) []float64 {
-	return funk.Map(percentages, func(p float64) float64 {
+	return Map(percentages, func(p float64) float64 {
 		return p * scoresTotal
-	}).([]float64)
+	})
 }
 
 func CalcPercentageOfSum(choice float64, choices []float64) float64 {
@@ -17,9 +66,7 @@ func CalcPercentageOfSum(choice float64, choices []float64) float64 {
 		return 0.0
 	}
 
-	whole := funk.Reduce(choices, func(acc float64, c float64) float64 {
-		return acc + c
-	}, 0).(float64)
+	whole := Sum(choices)
 
 	if whole == 0.0 {
 		return 0.0
@@ -31,3 +78,358 @@ func CalcPercentageOfSum(choice float64, choices []float64) float64 {
 func FloatEqual(a, b float64) bool {
 	return math.Abs(a-b) < 0.0000001
 }
+
+type RoundingMode int
+
+const (
+	RoundHalfUp RoundingMode = iota
+	RoundHalfEven
+	RoundDown
+)
+
+// UnitsToRat converts integer base units of a token with the given decimals
+// to a token amount.
+func UnitsToRat(units *big.Int, decimals int) *big.Rat {
+	return new(big.Rat).SetFrac(units, decimalScale(decimals))
+}
+
+// RatToUnits converts a token amount to integer base units, rounding toward
+// zero.
+func RatToUnits(x *big.Rat, decimals int) *big.Int {
+	num := new(big.Int).Mul(x.Num(), decimalScale(decimals))
+	return num.Quo(num, x.Denom())
+}
+
+// ExactValue returns the most precise of a vote's amounts: units, then
+// exact, then the exact value of f. Non-finite floats become zero.
+func ExactValue(units *big.Int, exact *big.Rat, f float64, decimals int) *big.Rat {
+	if units != nil {
+		return UnitsToRat(units, decimals)
+	}
+
+	if exact != nil {
+		return new(big.Rat).Set(exact)
+	}
+
+	r := new(big.Rat)
+	if r.SetFloat64(f) == nil {
+		return new(big.Rat)
+	}
+	return r
+}
+
+func ExactValues(units []*big.Int, exact []*big.Rat, floats []float64, decimals int) []*big.Rat {
+	rats := []*big.Rat{}
+
+	switch {
+	case units != nil:
+		for _, u := range units {
+			rats = append(rats, UnitsToRat(u, decimals))
+		}
+	case exact != nil:
+		for _, r := range exact {
+			rats = append(rats, new(big.Rat).Set(r))
+		}
+	default:
+		for _, f := range floats {
+			rats = append(rats, ExactValue(nil, nil, f, decimals))
+		}
+	}
+
+	return rats
+}
+
+// UnitsValue returns units, or the other amounts converted to base units
+// and rounded toward zero.
+func UnitsValue(units *big.Int, exact *big.Rat, f float64, decimals int) *big.Int {
+	if units != nil {
+		return new(big.Int).Set(units)
+	}
+	return RatToUnits(ExactValue(nil, exact, f, decimals), decimals)
+}
+
+func UnitsValues(units []*big.Int, exact []*big.Rat, floats []float64, decimals int) []*big.Int {
+	if units != nil {
+		values := []*big.Int{}
+		for _, u := range units {
+			values = append(values, new(big.Int).Set(u))
+		}
+		return values
+	}
+
+	values := []*big.Int{}
+	for _, r := range ExactValues(nil, exact, floats, decimals) {
+		values = append(values, RatToUnits(r, decimals))
+	}
+	return values
+}
+
+func NewInts(n int) []*big.Int {
+	ints := []*big.Int{}
+	for i := 0; i < n; i++ {
+		ints = append(ints, new(big.Int))
+	}
+	return ints
+}
+
+func NewIntMatrix(rows int, cols int) [][]*big.Int {
+	matrix := [][]*big.Int{}
+	for i := 0; i < rows; i++ {
+		matrix = append(matrix, NewInts(cols))
+	}
+	return matrix
+}
+
+func decimalScale(decimals int) *big.Int {
+	if decimals < 0 {
+		decimals = 0
+	}
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+}
+
+func NewRats(n int) []*big.Rat {
+	rats := []*big.Rat{}
+	for i := 0; i < n; i++ {
+		rats = append(rats, new(big.Rat))
+	}
+	return rats
+}
+
+func NewRatMatrix(rows int, cols int) [][]*big.Rat {
+	matrix := [][]*big.Rat{}
+	for i := 0; i < rows; i++ {
+		matrix = append(matrix, NewRats(cols))
+	}
+	return matrix
+}
+
+func RatsToFloats(rats []*big.Rat) []float64 {
+	floats := []float64{}
+	for _, r := range rats {
+		f, _ := r.Float64()
+		floats = append(floats, f)
+	}
+	return floats
+}
+
+func RatMatrixToFloats(matrix [][]*big.Rat) [][]float64 {
+	floats := [][]float64{}
+	for _, rats := range matrix {
+		floats = append(floats, RatsToFloats(rats))
+	}
+	return floats
+}
+
+func CalcReducedQuadraticScoresRat(scoresTotal *big.Rat, percentages []*big.Rat) []*big.Rat {
+	scores := []*big.Rat{}
+	for _, p := range percentages {
+		scores = append(scores, new(big.Rat).Mul(p, scoresTotal))
+	}
+	return scores
+}
+
+func CalcPercentageOfSumRat(choice *big.Rat, choices []*big.Rat) *big.Rat {
+	if choice.Sign() == 0 {
+		return new(big.Rat)
+	}
+
+	whole := new(big.Rat)
+	for _, c := range choices {
+		whole.Add(whole, c)
+	}
+
+	if whole.Sign() == 0 {
+		return new(big.Rat)
+	}
+
+	return new(big.Rat).Quo(choice, whole)
+}
+
+// RoundRat rounds x to the given number of decimal places.
+func RoundRat(x *big.Rat, decimals int, mode RoundingMode) *big.Rat {
+	if decimals < 0 {
+		decimals = 0
+	}
+
+	scale := decimalScale(decimals)
+	num := new(big.Int).Mul(x.Num(), scale)
+	quotient, remainder := new(big.Int).QuoRem(num, x.Denom(), new(big.Int))
+
+	twiceRemainder := new(big.Int).Abs(remainder)
+	twiceRemainder.Lsh(twiceRemainder, 1)
+	half := twiceRemainder.Cmp(x.Denom())
+
+	roundAway := false
+	switch mode {
+	case RoundHalfUp:
+		roundAway = half >= 0
+	case RoundHalfEven:
+		roundAway = half > 0 || (half == 0 && quotient.Bit(0) == 1)
+	}
+
+	if roundAway && remainder.Sign() != 0 {
+		quotient.Add(quotient, big.NewInt(int64(x.Sign())))
+	}
+
+	return new(big.Rat).SetFrac(quotient, scale)
+}
+
+// accumulatorPrecision holds any sum of up to 2^64 float64 values exactly:
+// they span 2^-1074 to 2^1024.
+const accumulatorPrecision = 1074 + 1024 + 64
+
+// Accumulator sums float64 values without rounding, so the sum does not
+// depend on the order the values were added in. The zero value is an empty
+// sum. Values must be finite.
+//
+// The sum is kept as non-overlapping float64 partials whose exact total is
+// the sum, as in Shewchuk's adaptive-precision addition, so adding a value
+// costs a few float operations and does not allocate once the partials
+// have grown. Only a sum that overflows float64 switches to a big.Float.
+type Accumulator struct {
+	partials []float64
+	big      *big.Float
+	scratch  big.Float
+}
+
+func (a *Accumulator) Add(x float64) {
+	if a.big != nil {
+		a.big.Add(a.big, a.scratch.SetFloat64(x))
+		return
+	}
+
+	i := 0
+	for j, y := range a.partials {
+		if math.Abs(x) < math.Abs(y) {
+			x, y = y, x
+		}
+		hi := x + y
+		if math.IsInf(hi, 0) {
+			a.partials = append(a.partials[:i], a.partials[j+1:]...)
+			a.spill()
+			a.big.Add(a.big, a.scratch.SetFloat64(x))
+			a.big.Add(a.big, a.scratch.SetFloat64(y))
+			return
+		}
+		lo := y - (hi - x)
+		if lo != 0 {
+			a.partials[i] = lo
+			i++
+		}
+		x = hi
+	}
+	a.partials = append(a.partials[:i], x)
+}
+
+// spill moves the partials into a big.Float.
+func (a *Accumulator) spill() {
+	a.big = new(big.Float).SetPrec(accumulatorPrecision)
+	for _, p := range a.partials {
+		a.big.Add(a.big, a.scratch.SetFloat64(p))
+	}
+	a.partials = nil
+}
+
+func (a *Accumulator) Sub(x float64) {
+	a.Add(-x)
+}
+
+func (a *Accumulator) Merge(other *Accumulator) {
+	if other.big != nil {
+		if a.big == nil {
+			a.spill()
+		}
+		a.big.Add(a.big, other.big)
+		return
+	}
+
+	for _, p := range other.partials {
+		a.Add(p)
+	}
+}
+
+// Float64 returns the sum rounded to the nearest float64, ties to even.
+func (a *Accumulator) Float64() float64 {
+	if a.big != nil {
+		f, _ := a.big.Float64()
+		return f
+	}
+
+	// Add the partials from the largest down until the low part of the sum
+	// is non-zero; the partials below it can only matter to break a tie.
+	n := len(a.partials)
+	if n == 0 {
+		return 0
+	}
+	n--
+	hi, lo := a.partials[n], float64(0)
+	for n > 0 {
+		x := hi
+		n--
+		y := a.partials[n]
+		hi = x + y
+		lo = y - (hi - x)
+		if lo != 0 {
+			break
+		}
+	}
+
+	// hi + lo is exactly halfway between two floats and rounded to even;
+	// when the next partial pushes the same way, round the other way.
+	if n > 0 && ((lo < 0 && a.partials[n-1] < 0) || (lo > 0 && a.partials[n-1] > 0)) {
+		y := lo * 2
+		x := hi + y
+		if y == x-hi {
+			hi = x
+		}
+	}
+	return hi
+}
+
+func AccumulatorsToFloats(accumulators []Accumulator) []float64 {
+	floats := []float64{}
+	for idx := range accumulators {
+		floats = append(floats, accumulators[idx].Float64())
+	}
+	return floats
+}
+
+// SortedChoiceKeys returns the keys of a weighted, quadratic or score choice
+// in numeric order, with non-numeric keys last, so the choice can be walked
+// in the same order every time.
+func SortedChoiceKeys[V any](choice map[string]V) []string {
+	keys := []string{}
+	for k := range choice {
+		keys = append(keys, k)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		numI, errI := strconv.Atoi(keys[i])
+		numJ, errJ := strconv.Atoi(keys[j])
+		switch {
+		case errI == nil && errJ == nil:
+			return numI < numJ
+		case errI == nil || errJ == nil:
+			return errI == nil
+		}
+		return keys[i] < keys[j]
+	})
+
+	return keys
+}
+
+func NewAccumulatorMatrix(rows int, cols int) [][]Accumulator {
+	matrix := [][]Accumulator{}
+	for i := 0; i < rows; i++ {
+		matrix = append(matrix, make([]Accumulator, cols))
+	}
+	return matrix
+}
+
+func AccumulatorMatrixToFloats(matrix [][]Accumulator) [][]float64 {
+	floats := [][]float64{}
+	for _, accumulators := range matrix {
+		floats = append(floats, AccumulatorsToFloats(accumulators))
+	}
+	return floats
+}
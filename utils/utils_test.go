@@ -0,0 +1,196 @@
+package utils
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/thoas/go-funk"
+)
+
+func TestRoundRat(t *testing.T) {
+	tests := []struct {
+		x        string
+		decimals int
+		mode     RoundingMode
+		expected string
+	}{
+		{"1.2345", 3, RoundHalfUp, "1.235"},
+		{"1.2345", 3, RoundHalfEven, "1.234"},
+		{"1.2355", 3, RoundHalfEven, "1.236"},
+		{"1.2349", 3, RoundDown, "1.234"},
+		{"-1.2345", 3, RoundHalfUp, "-1.235"},
+		{"-1.2345", 3, RoundDown, "-1.234"},
+		{"2/3", 18, RoundHalfUp, "0.666666666666666667"},
+		{"2/3", 0, RoundHalfUp, "1"},
+		{"7", 2, RoundHalfEven, "7.00"},
+	}
+
+	for _, test := range tests {
+		x, ok := new(big.Rat).SetString(test.x)
+		if !ok {
+			t.Fatalf("Invalid rational %s", test.x)
+		}
+
+		rounded := RoundRat(x, test.decimals, test.mode).FloatString(test.decimals)
+		if rounded != test.expected {
+			t.Errorf("Expected %s rounded to %d decimals to be %s, got %s", test.x, test.decimals, test.expected, rounded)
+		}
+	}
+}
+
+func TestGenerics(t *testing.T) {
+	values := []float64{0.1, 0.2, 0.3, 0.4}
+
+	filtered := Filter(values, func(v float64) bool { return v > 0.15 })
+	if !reflect.DeepEqual(filtered, []float64{0.2, 0.3, 0.4}) {
+		t.Errorf("Expected filtered values %v, got %v", []float64{0.2, 0.3, 0.4}, filtered)
+	}
+	if empty := Filter([]int{}, func(int) bool { return true }); empty == nil || len(empty) != 0 {
+		t.Errorf("Expected an empty non-nil slice, got %#v", empty)
+	}
+
+	doubled := Map([]int{1, 2, 3}, func(v int) float64 { return float64(v) * 2 })
+	if !reflect.DeepEqual(doubled, []float64{2, 4, 6}) {
+		t.Errorf("Expected mapped values %v, got %v", []float64{2, 4, 6}, doubled)
+	}
+
+	joined := Reduce([]int{1, 2, 3}, func(acc string, v int) string { return acc + string(rune('0'+v)) }, ">")
+	if joined != ">123" {
+		t.Errorf("Expected reduced value %q, got %q", ">123", joined)
+	}
+
+	flattened := Flatten([][]int{{1, 2}, {}, {3}})
+	if !reflect.DeepEqual(flattened, []int{1, 2, 3}) {
+		t.Errorf("Expected flattened values %v, got %v", []int{1, 2, 3}, flattened)
+	}
+
+	expected := funk.Reduce(values, func(acc float64, v float64) float64 { return acc + v }, 0).(float64)
+	if sum := Sum(values); sum != expected {
+		t.Errorf("Expected sum %v to round like funk.Reduce %v", sum, expected)
+	}
+	if percentage := CalcPercentageOfSum(0.3, values); percentage != 0.3/expected {
+		t.Errorf("Expected percentage %v, got %v", 0.3/expected, percentage)
+	}
+}
+
+func TestAccumulator(t *testing.T) {
+	tests := []struct {
+		values   []float64
+		expected float64
+	}{
+		{[]float64{}, 0},
+		{[]float64{0.1, 0.2, 0.3}, 0.6},
+		{[]float64{1e16, 1, -1e16}, 1},
+		{[]float64{1, 1e100, 1, -1e100}, 2},
+		// 2^53 + 1 is halfway and rounds to even, but the tiny third value
+		// pushes it up.
+		{[]float64{1 << 53, 1, 1e-300}, 1<<53 + 2},
+		{[]float64{1 << 53, 1}, 1 << 53},
+		// The running sum overflows float64 before it comes back.
+		{[]float64{math.MaxFloat64, math.MaxFloat64, -math.MaxFloat64}, math.MaxFloat64},
+	}
+
+	for _, test := range tests {
+		for _, order := range [][]float64{test.values, reversed(test.values)} {
+			a := Accumulator{}
+			for _, v := range order {
+				a.Add(v)
+			}
+			if sum := a.Float64(); sum != test.expected {
+				t.Errorf("Expected %v to sum to %v, got %v", order, test.expected, sum)
+			}
+		}
+	}
+
+	values := benchmarkValues(1000)
+	whole, first, second := Accumulator{}, Accumulator{}, Accumulator{}
+	for idx, v := range values {
+		whole.Add(v)
+		if idx%2 == 0 {
+			first.Add(v)
+		} else {
+			second.Add(v)
+		}
+	}
+	first.Merge(&second)
+	if first.Float64() != whole.Float64() {
+		t.Errorf("Expected merged sum %v, got %v", whole.Float64(), first.Float64())
+	}
+
+	for _, v := range values[1:] {
+		whole.Sub(v)
+	}
+	if whole.Float64() != values[0] {
+		t.Errorf("Expected %v after subtracting the rest, got %v", values[0], whole.Float64())
+	}
+}
+
+func reversed(values []float64) []float64 {
+	r := []float64{}
+	for idx := len(values) - 1; idx >= 0; idx-- {
+		r = append(r, values[idx])
+	}
+	return r
+}
+
+func benchmarkValues(n int) []float64 {
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = float64(i%1000) / 7
+	}
+	return values
+}
+
+func BenchmarkFilter(b *testing.B) {
+	values := benchmarkValues(100000)
+	keep := func(v float64) bool { return v > 50 }
+
+	b.Run("generic", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			Filter(values, keep)
+		}
+	})
+	b.Run("funk", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = funk.Filter(values, keep).([]float64)
+		}
+	})
+}
+
+func BenchmarkReduce(b *testing.B) {
+	values := benchmarkValues(100000)
+
+	b.Run("generic", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			Sum(values)
+		}
+	})
+	b.Run("funk", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = funk.Reduce(values, func(acc float64, v float64) float64 { return acc + v }, 0).(float64)
+		}
+	})
+}
+
+func BenchmarkCalcReducedQuadraticScores(b *testing.B) {
+	values := benchmarkValues(100000)
+
+	b.Run("generic", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			CalcReducedQuadraticScores(10, values)
+		}
+	})
+	b.Run("funk", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = funk.Map(values, func(p float64) float64 { return p * 10 }).([]float64)
+		}
+	})
+}
@@ -0,0 +1,51 @@
+package voting
+
+import (
+	"strings"
+)
+
+type DedupPolicy int
+
+const (
+	LatestVote DedupPolicy = iota
+	FirstVote
+)
+
+// Deduplicate keeps one vote per voter, chosen by Created according to
+// policy; among votes with the same Created, the one listed last (for
+// LatestVote) or first (for FirstVote) is kept. Voters are compared case
+// insensitively, so checksummed and lowercase addresses match, and votes
+// without a voter are always kept. Both slices keep the original order.
+func Deduplicate[V Vote](votes []V, policy DedupPolicy) ([]V, []V) {
+	keptByVoter := make(map[string]int)
+
+	for idx, vote := range votes {
+		voter := strings.ToLower(vote.GetVoter())
+		if voter == "" {
+			continue
+		}
+
+		keptIdx, ok := keptByVoter[voter]
+		if !ok {
+			keptByVoter[voter] = idx
+			continue
+		}
+
+		created, keptCreated := vote.GetCreated(), votes[keptIdx].GetCreated()
+		if (policy == LatestVote && created >= keptCreated) || (policy == FirstVote && created < keptCreated) {
+			keptByVoter[voter] = idx
+		}
+	}
+
+	kept, superseded := []V{}, []V{}
+	for idx, vote := range votes {
+		voter := strings.ToLower(vote.GetVoter())
+		if voter == "" || keptByVoter[voter] == idx {
+			kept = append(kept, vote)
+		} else {
+			superseded = append(superseded, vote)
+		}
+	}
+
+	return kept, superseded
+}
@@ -0,0 +1,189 @@
+package voting
+
+import (
+	"math/big"
+
+	"github.com/This-Is-Prince/votingSystemGo/utils"
+)
+
+// ExactVotingSystem tallies with rational arithmetic. Votes are read from
+// their BalanceUnits and ScoresUnits, as base units of a token with the
+// proposal's Decimals, when set; then from ExactBalance and ExactScores;
+// and from the exact value of their float64 Balance and Scores otherwise.
+type ExactVotingSystem interface {
+	VotingSystem
+	GetScoresTotalExact() *big.Rat
+	GetScoresExact() []*big.Rat
+	GetScoresByStrategyExact() [][]*big.Rat
+}
+
+// UnitsVotingSystem tallies integer base units of a token with no
+// conversion through floats. Votes without BalanceUnits or ScoresUnits have
+// their other amounts converted to base units, rounded toward zero.
+type UnitsVotingSystem interface {
+	VotingSystem
+	GetScoresTotalUnits() *big.Int
+	GetScoresUnits() []*big.Int
+	GetScoresByStrategyUnits() [][]*big.Int
+}
+
+// ExactResult is an exact tally rounded to Decimals places, kept as decimal
+// strings so no precision is lost in JSON.
+type ExactResult struct {
+	Scores           []string   `json:"scores"`
+	ScoresByStrategy [][]string `json:"scoresByStrategy"`
+	Strategies       []string   `json:"strategies"`
+	ScoresTotal      string     `json:"scoresTotal"`
+	Decimals         int        `json:"decimals"`
+}
+
+func NewExactResult(v ExactVotingSystem, decimals int, mode utils.RoundingMode) ExactResult {
+	result := ExactResult{
+		Scores:           []string{},
+		ScoresByStrategy: [][]string{},
+		Strategies:       StrategyLabels(v.GetStrategies()),
+		ScoresTotal:      FormatRat(v.GetScoresTotalExact(), decimals, mode),
+		Decimals:         decimals,
+	}
+
+	for _, score := range v.GetScoresExact() {
+		result.Scores = append(result.Scores, FormatRat(score, decimals, mode))
+	}
+
+	for _, scores := range v.GetScoresByStrategyExact() {
+		formatted := []string{}
+		for _, score := range scores {
+			formatted = append(formatted, FormatRat(score, decimals, mode))
+		}
+		result.ScoresByStrategy = append(result.ScoresByStrategy, formatted)
+	}
+
+	return result
+}
+
+func FormatRat(x *big.Rat, decimals int, mode utils.RoundingMode) string {
+	if decimals < 0 {
+		decimals = 0
+	}
+	return utils.RoundRat(x, decimals, mode).FloatString(decimals)
+}
+
+// ValidateAmounts checks every amount of a vote with ValidatePower,
+// ValidateExactPower and ValidateUnitsPower. Float scores may be left out
+// when exact or unit scores are given.
+func ValidateAmounts(balance float64, scores []float64, exactBalance *big.Rat, exactScores []*big.Rat, balanceUnits *big.Int, scoresUnits []*big.Int, strategies int) error {
+	floatStrategies := strategies
+	if scores == nil && (exactScores != nil || scoresUnits != nil) {
+		floatStrategies = 0
+	}
+
+	if err := ValidatePower(balance, scores, floatStrategies); err != nil {
+		return err
+	}
+
+	if err := ValidateExactPower(exactBalance, exactScores, strategies); err != nil {
+		return err
+	}
+
+	return ValidateUnitsPower(balanceUnits, scoresUnits, strategies)
+}
+
+// FloatAmounts returns a vote's balance and scores as float64, read like
+// the exact tally reads them: from base units with the proposal's decimals,
+// then from the exact amounts, then from the floats. Votes with only float
+// amounts are returned as they are.
+func FloatAmounts(balance float64, scores []float64, exactBalance *big.Rat, exactScores []*big.Rat, balanceUnits *big.Int, scoresUnits []*big.Int, decimals int) (float64, []float64) {
+	if balanceUnits != nil || exactBalance != nil {
+		balance, _ = utils.ExactValue(balanceUnits, exactBalance, balance, decimals).Float64()
+	}
+
+	if scoresUnits != nil || exactScores != nil {
+		scores = utils.RatsToFloats(utils.ExactValues(scoresUnits, exactScores, scores, decimals))
+	}
+
+	return balance, scores
+}
+
+// ExactVote is a vote whose balance can be read exactly, from base units
+// with the proposal's decimals when it has them.
+type ExactVote interface {
+	Vote
+	GetExactBalance(decimals int) *big.Rat
+}
+
+// GetDecimals returns the decimals of v's base units, or 0 when v has no
+// Decimals.
+func GetDecimals(v VotingSystem) int {
+	if d, ok := v.(interface{ GetDecimals() int }); ok {
+		return d.GetDecimals()
+	}
+	return 0
+}
+
+// VoteBalance returns the float64 balance of a vote of v, read like
+// FloatAmounts reads it.
+func VoteBalance(v VotingSystem, vote Vote) float64 {
+	exactVote, ok := vote.(ExactVote)
+	if !ok {
+		return vote.GetBalance()
+	}
+
+	balance, _ := exactVote.GetExactBalance(GetDecimals(v)).Float64()
+	return balance
+}
+
+// ValidateExactPower applies the checks of ValidatePower to a vote's exact
+// balance and scores, when they are set.
+func ValidateExactPower(balance *big.Rat, scores []*big.Rat, strategies int) error {
+	if balance != nil && balance.Sign() < 0 {
+		return NewValidationError(CodeNegativeBalance, "exact balance %s is negative", balance.RatString())
+	}
+
+	if scores == nil {
+		return nil
+	}
+
+	if len(scores) != strategies {
+		return NewValidationError(CodeScoresLength, "%d exact scores for %d strategies", len(scores), strategies)
+	}
+
+	for idx, score := range scores {
+		if score == nil {
+			return NewValidationError(CodeInvalidScore, "exact score %d is missing", idx)
+		}
+
+		if score.Sign() < 0 {
+			return NewValidationError(CodeNegativeScore, "exact score %d is %s, which is negative", idx, score.RatString())
+		}
+	}
+
+	return nil
+}
+
+// ValidateUnitsPower applies the checks of ValidatePower to a vote's balance
+// and scores in base units, when they are set.
+func ValidateUnitsPower(balance *big.Int, scores []*big.Int, strategies int) error {
+	if balance != nil && balance.Sign() < 0 {
+		return NewValidationError(CodeNegativeBalance, "balance of %s units is negative", balance)
+	}
+
+	if scores == nil {
+		return nil
+	}
+
+	if len(scores) != strategies {
+		return NewValidationError(CodeScoresLength, "%d unit scores for %d strategies", len(scores), strategies)
+	}
+
+	for idx, score := range scores {
+		if score == nil {
+			return NewValidationError(CodeInvalidScore, "unit score %d is missing", idx)
+		}
+
+		if score.Sign() < 0 {
+			return NewValidationError(CodeNegativeScore, "unit score %d is %s, which is negative", idx, score)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package voting
+
+import (
+	"runtime"
+	"sync"
+)
+
+// ParallelTally splits votes into contiguous shards, tallies each shard on
+// its own goroutine into a fresh partial from newPartial, and merges the
+// partials in shard order. A workers value of zero or less uses GOMAXPROCS.
+func ParallelTally[V any, T any](votes []V, workers int, newPartial func() T, tally func(partial T, vote V), merge func(into T, partial T)) T {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(votes) {
+		workers = len(votes)
+	}
+	if workers <= 1 {
+		partial := newPartial()
+		for _, vote := range votes {
+			tally(partial, vote)
+		}
+		return partial
+	}
+
+	partials := make([]T, workers)
+	shardSize := (len(votes) + workers - 1) / workers
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		start := w * shardSize
+		end := start + shardSize
+		if end > len(votes) {
+			end = len(votes)
+		}
+		partials[w] = newPartial()
+		if start >= end {
+			continue
+		}
+
+		wg.Add(1)
+		go func(partial T, shard []V) {
+			defer wg.Done()
+			for _, vote := range shard {
+				tally(partial, vote)
+			}
+		}(partials[w], votes[start:end])
+	}
+	wg.Wait()
+
+	result := partials[0]
+	for _, partial := range partials[1:] {
+		merge(result, partial)
+	}
+	return result
+}
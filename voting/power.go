@@ -0,0 +1,97 @@
+package voting
+
+import (
+	"math/big"
+
+	"github.com/This-Is-Prince/votingSystemGo/utils"
+)
+
+// GetMultiplier returns Multiplier, or 1 when it is unset.
+func (s Strategy) GetMultiplier() float64 {
+	if s.Multiplier == 0 {
+		return 1
+	}
+	return s.Multiplier
+}
+
+// Apply returns the power a voter gets from score under the strategy's
+// multiplier and cap.
+func (s Strategy) Apply(score float64) float64 {
+	power := score * s.GetMultiplier()
+	if s.Cap > 0 && power > s.Cap {
+		return s.Cap
+	}
+	return power
+}
+
+func (s Strategy) applyExact(score *big.Rat) *big.Rat {
+	power := new(big.Rat).Set(score)
+	if multiplier := new(big.Rat); multiplier.SetFloat64(s.GetMultiplier()) != nil {
+		power.Mul(power, multiplier)
+	}
+
+	if s.Cap > 0 {
+		if limit := new(big.Rat); limit.SetFloat64(s.Cap) != nil && power.Cmp(limit) > 0 {
+			return limit
+		}
+	}
+	return power
+}
+
+// AdjustsPower reports whether any strategy has a multiplier or a cap.
+func AdjustsPower(strategies []Strategy) bool {
+	for _, strategy := range strategies {
+		if strategy.GetMultiplier() != 1 || strategy.Cap > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// EffectivePower returns a vote's balance and scores after the strategies'
+// multipliers and caps. When any strategy adjusts power, the balance becomes
+// the sum of the adjusted scores; otherwise, or when the vote has no score
+// per strategy, the balance and scores are returned as they are.
+func EffectivePower(strategies []Strategy, balance float64, scores []float64) (float64, []float64) {
+	if !AdjustsPower(strategies) || len(scores) != len(strategies) {
+		return balance, scores
+	}
+
+	power := []float64{}
+	for idx, score := range scores {
+		power = append(power, strategies[idx].Apply(score))
+	}
+	return utils.Sum(power), power
+}
+
+func EffectivePowerExact(strategies []Strategy, balance *big.Rat, scores []*big.Rat) (*big.Rat, []*big.Rat) {
+	if !AdjustsPower(strategies) || len(scores) != len(strategies) {
+		return balance, scores
+	}
+
+	total := new(big.Rat)
+	power := []*big.Rat{}
+	for idx, score := range scores {
+		p := strategies[idx].applyExact(score)
+		total.Add(total, p)
+		power = append(power, p)
+	}
+	return total, power
+}
+
+// EffectivePowerUnits is EffectivePower for base units. Each adjusted score
+// is rounded toward zero, and the balance is the sum of the rounded scores.
+func EffectivePowerUnits(strategies []Strategy, decimals int, balance *big.Int, scores []*big.Int) (*big.Int, []*big.Int) {
+	if !AdjustsPower(strategies) || len(scores) != len(strategies) {
+		return balance, scores
+	}
+
+	total := new(big.Int)
+	power := []*big.Int{}
+	for idx, score := range scores {
+		p := utils.RatToUnits(strategies[idx].applyExact(utils.UnitsToRat(score, decimals)), decimals)
+		total.Add(total, p)
+		power = append(power, p)
+	}
+	return total, power
+}
@@ -0,0 +1,98 @@
+package voting
+
+import (
+	"github.com/This-Is-Prince/votingSystemGo/utils"
+)
+
+// Result is the outcome of a tally. Winners holds the 1-based choices with
+// the highest score; there is more than one when they are tied. Winner is
+// the single winning choice, which for a tie is only set once a TieSettler
+// or TieBreaker has been applied, and TieBreak names that rule. Strategies
+// labels the columns of ScoresByStrategy.
+type Result struct {
+	Scores           []float64   `json:"scores"`
+	ScoresByStrategy [][]float64 `json:"scoresByStrategy"`
+	Strategies       []string    `json:"strategies"`
+	ScoresTotal      float64     `json:"scoresTotal"`
+	Percentages      []float64   `json:"percentages"`
+	Winners          []int       `json:"winners"`
+	Tie              bool        `json:"tie"`
+	Winner           int         `json:"winner"`
+	TieBreak         string      `json:"tieBreak,omitempty"`
+	ValidVotes       int         `json:"validVotes"`
+	InvalidVotes     int         `json:"invalidVotes"`
+}
+
+func NewResult(v VotingSystem) Result {
+	validVotes := len(GetValidVotes(v))
+	result := Result{
+		Scores:           v.GetScores(),
+		ScoresByStrategy: v.GetScoresByStrategy(),
+		Strategies:       StrategyLabels(v.GetStrategies()),
+		ScoresTotal:      v.GetScoresTotal(),
+		Percentages:      []float64{},
+		ValidVotes:       validVotes,
+		InvalidVotes:     len(v.GetVotes()) - validVotes,
+	}
+
+	if p, ok := v.(PercentageSystem); ok {
+		result.Percentages = p.GetPercentages()
+	} else {
+		for _, score := range result.Scores {
+			percentage := float64(0)
+			if result.ScoresTotal != 0 {
+				percentage = score / result.ScoresTotal
+			}
+			result.Percentages = append(result.Percentages, percentage)
+		}
+	}
+
+	result.Winners = GetWinners(result.Scores)
+	result.Tie = len(result.Winners) > 1
+	if len(result.Winners) == 1 {
+		result.Winner = result.Winners[0]
+	} else if s, ok := v.(TieSettler); ok {
+		if winner, tieBreak := s.SettleTie(); winner != 0 {
+			result.Winner, result.TieBreak = winner, tieBreak
+		}
+	}
+
+	return result
+}
+
+func NewResultWithTieBreaker(v VotingSystem, tieBreaker TieBreaker) Result {
+	result := NewResult(v)
+	if result.Tie && result.TieBreak == "" {
+		result.Winner = tieBreaker.Break(v, result.Winners)
+		result.TieBreak = tieBreaker.Name()
+	}
+
+	return result
+}
+
+// GetWinners returns the 1-based choices whose score equals the highest
+// score, or no choices when every score is zero. Scores may be negative, as
+// on a score scale that starts below zero.
+func GetWinners(scores []float64) []int {
+	winners := []int{}
+	highest := float64(0)
+	scored := false
+
+	for idx, score := range scores {
+		switch {
+		case len(winners) > 0 && utils.FloatEqual(score, highest):
+			winners = append(winners, idx+1)
+		case len(winners) == 0 || score > highest:
+			highest = score
+			winners = []int{idx + 1}
+		}
+		if !utils.FloatEqual(score, 0) {
+			scored = true
+		}
+	}
+
+	if !scored {
+		return []int{}
+	}
+	return winners
+}
@@ -0,0 +1,129 @@
+package voting
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// Strategy describes how one column of a vote's Scores was computed, such
+// as a token balance on a given network. Multiplier scales the strategy's
+// power when combining strategies; zero means 1, so a zero multiplier cannot
+// switch a strategy off. Cap limits the power one voter gets from the
+// strategy after the multiplier; zero means no cap. Both must be finite and
+// non-negative, or the proposal counts no vote.
+type Strategy struct {
+	Name       string                 `json:"name"`
+	Network    string                 `json:"network,omitempty"`
+	Params     map[string]interface{} `json:"params,omitempty"`
+	Multiplier float64                `json:"multiplier,omitempty"`
+	Cap        float64                `json:"cap,omitempty"`
+	Label      string                 `json:"label,omitempty"`
+}
+
+// UnmarshalJSON accepts a strategy object, and for proposals written before
+// strategies were typed, a bare name or any other JSON value. A value that
+// is neither an object nor a string is kept as the strategy's name, except
+// null, which leaves the strategy unchanged as encoding/json does.
+func (s *Strategy) UnmarshalJSON(data []byte) error {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return fmt.Errorf("empty strategy")
+	}
+
+	if bytes.Equal(trimmed, []byte("null")) {
+		return nil
+	}
+
+	switch trimmed[0] {
+	case '{':
+		type strategy Strategy
+		decoded := strategy{}
+		if err := json.Unmarshal(trimmed, &decoded); err != nil {
+			return err
+		}
+		*s = Strategy(decoded)
+	case '"':
+		*s = Strategy{}
+		return json.Unmarshal(trimmed, &s.Name)
+	default:
+		*s = Strategy{Name: string(trimmed)}
+	}
+
+	return nil
+}
+
+// Validate rejects a multiplier or cap that is negative or not finite.
+func (s Strategy) Validate() error {
+	if math.IsNaN(s.Multiplier) || math.IsInf(s.Multiplier, 0) || s.Multiplier < 0 {
+		return NewValidationError(CodeInvalidStrategy, "strategy %q has multiplier %v, not a finite, non-negative number", s.GetLabel(), s.Multiplier)
+	}
+
+	if math.IsNaN(s.Cap) || math.IsInf(s.Cap, 0) || s.Cap < 0 {
+		return NewValidationError(CodeInvalidStrategy, "strategy %q has cap %v, not a finite, non-negative number", s.GetLabel(), s.Cap)
+	}
+
+	return nil
+}
+
+// ValidateStrategies validates every strategy of a proposal. Voting types
+// check it with every vote, so a misconfigured proposal counts nothing.
+func ValidateStrategies(strategies []Strategy) error {
+	for _, strategy := range strategies {
+		if err := strategy.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetLabel returns the display label of the strategy, falling back to its
+// name.
+func (s Strategy) GetLabel() string {
+	if s.Label != "" {
+		return s.Label
+	}
+	return s.Name
+}
+
+// StrategyLabels returns a label for every strategy, in order. Strategies
+// with neither a label nor a name are called "strategy N", N being 1-based.
+func StrategyLabels(strategies []Strategy) []string {
+	labels := []string{}
+	for idx, strategy := range strategies {
+		label := strategy.GetLabel()
+		if label == "" {
+			label = fmt.Sprintf("strategy %d", idx+1)
+		}
+		labels = append(labels, label)
+	}
+	return labels
+}
+
+// LabelScoresByStrategy keys each choice's scores by strategy label. Labels
+// that repeat get their 1-based position appended, so no score is lost.
+func LabelScoresByStrategy(strategies []Strategy, scoresByStrategy [][]float64) []map[string]float64 {
+	labels := StrategyLabels(strategies)
+	seen := map[string]int{}
+	for _, label := range labels {
+		seen[label]++
+	}
+	for idx, label := range labels {
+		if seen[label] > 1 {
+			labels[idx] = fmt.Sprintf("%s #%d", label, idx+1)
+		}
+	}
+
+	labelled := []map[string]float64{}
+	for _, scores := range scoresByStrategy {
+		byLabel := map[string]float64{}
+		for idx, score := range scores {
+			if idx < len(labels) {
+				byLabel[labels[idx]] = score
+			}
+		}
+		labelled = append(labelled, byLabel)
+	}
+	return labelled
+}
@@ -0,0 +1,123 @@
+package voting
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"unicode"
+)
+
+var ErrNotVoteArray = errors.New("votes are not a JSON array")
+
+// Streamer is implemented by voting types whose votes can be tallied one at a
+// time, without holding the whole proposal in memory.
+type Streamer interface {
+	NewTallier() Tallier
+	DecodeVote(dec *json.Decoder) (Vote, error)
+}
+
+// StreamStats counts the votes read from a stream. Rejected votes failed
+// validation and were left out of the tally, as GetScores would.
+type StreamStats struct {
+	Votes    int `json:"votes"`
+	Rejected int `json:"rejected"`
+}
+
+// VoteDecoder reads votes one by one from a JSON array or from JSON Lines.
+type VoteDecoder struct {
+	dec     *json.Decoder
+	decoder Streamer
+	array   bool
+	started bool
+	done    bool
+}
+
+// NewVoteDecoder reads votes for s from r, which holds either a JSON array of
+// votes or one vote per line.
+func NewVoteDecoder(r io.Reader, s Streamer) *VoteDecoder {
+	br := bufio.NewReader(r)
+	array := false
+	for {
+		c, _, err := br.ReadRune()
+		if err != nil {
+			break
+		}
+		if !unicode.IsSpace(c) {
+			array = c == '['
+			br.UnreadRune()
+			break
+		}
+	}
+
+	return &VoteDecoder{dec: json.NewDecoder(br), decoder: s, array: array}
+}
+
+// NewVoteArrayDecoder reads votes for s from the JSON array that dec is about
+// to decode, such as the "votes" field of a proposal document.
+func NewVoteArrayDecoder(dec *json.Decoder, s Streamer) *VoteDecoder {
+	return &VoteDecoder{dec: dec, decoder: s, array: true}
+}
+
+// Next returns the next vote, or io.EOF once the votes are exhausted.
+func (d *VoteDecoder) Next() (Vote, error) {
+	if d.done {
+		return nil, io.EOF
+	}
+
+	if d.array {
+		if !d.started {
+			d.started = true
+			token, err := d.dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			if delim, ok := token.(json.Delim); !ok || delim != '[' {
+				return nil, fmt.Errorf("%w: got %v", ErrNotVoteArray, token)
+			}
+		}
+
+		if !d.dec.More() {
+			d.done = true
+			if _, err := d.dec.Token(); err != nil {
+				return nil, err
+			}
+			return nil, io.EOF
+		}
+	}
+
+	vote, err := d.decoder.DecodeVote(d.dec)
+	if err == io.EOF && !d.array {
+		d.done = true
+	}
+	return vote, err
+}
+
+// TallyVotes adds every vote from d to t. Invalid votes are counted as
+// rejected and skipped; a malformed stream stops the tally with an error.
+func TallyVotes(d *VoteDecoder, t Tallier) (StreamStats, error) {
+	stats := StreamStats{}
+	for {
+		vote, err := d.Next()
+		if err == io.EOF {
+			return stats, nil
+		}
+		if err != nil {
+			return stats, fmt.Errorf("decoding vote %d: %w", stats.Votes+1, err)
+		}
+
+		stats.Votes++
+		if err := t.AddVote(vote); err != nil {
+			stats.Rejected++
+		}
+	}
+}
+
+// TallyStream tallies the votes read from r, a JSON array of votes or JSON
+// Lines, into the Tallier of s.
+func TallyStream(s Streamer, r io.Reader) (Tallier, StreamStats, error) {
+	t := s.NewTallier()
+	stats, err := TallyVotes(NewVoteDecoder(r, s), t)
+	return t, stats, err
+}
@@ -0,0 +1,73 @@
+package voting
+
+import "sync"
+
+// Snapshot is a consistent view of a tally: the total, scores and scores by
+// strategy were all read without a vote landing in between.
+type Snapshot struct {
+	ScoresTotal      float64     `json:"scoresTotal"`
+	Scores           []float64   `json:"scores"`
+	ScoresByStrategy [][]float64 `json:"scoresByStrategy"`
+}
+
+// SyncTallier makes a Tallier safe to use from many goroutines. Writers take
+// the lock only for the O(choices × strategies) update of the wrapped
+// tallier, and readers share it.
+type SyncTallier struct {
+	mu      sync.RWMutex
+	tallier Tallier
+}
+
+var _ Tallier = (*SyncTallier)(nil)
+
+func NewSyncTallier(tallier Tallier) *SyncTallier {
+	return &SyncTallier{tallier: tallier}
+}
+
+func (t *SyncTallier) AddVote(vote Vote) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.tallier.AddVote(vote)
+}
+
+func (t *SyncTallier) RemoveVote(vote Vote) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.tallier.RemoveVote(vote)
+}
+
+func (t *SyncTallier) ReplaceVote(oldVote Vote, newVote Vote) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.tallier.ReplaceVote(oldVote, newVote)
+}
+
+func (t *SyncTallier) GetScoresTotal() float64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.tallier.GetScoresTotal()
+}
+
+func (t *SyncTallier) GetScores() []float64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.tallier.GetScores()
+}
+
+func (t *SyncTallier) GetScoresByStrategy() [][]float64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.tallier.GetScoresByStrategy()
+}
+
+// Snapshot reads the total, scores and scores by strategy under one read
+// lock, so they always describe the same set of votes.
+func (t *SyncTallier) Snapshot() Snapshot {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return Snapshot{
+		ScoresTotal:      t.tallier.GetScoresTotal(),
+		Scores:           t.tallier.GetScores(),
+		ScoresByStrategy: t.tallier.GetScoresByStrategy(),
+	}
+}
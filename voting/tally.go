@@ -0,0 +1,139 @@
+package voting
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/This-Is-Prince/votingSystemGo/utils"
+)
+
+// RunningTally implements Tallier for votes of type V. Voting types embed it
+// in their own Tallier and supply the add function that counts one vote,
+// with a sign of 1 to add it or -1 to take it back. It remembers how many
+// times each vote was added, so removing a vote it does not hold is an error
+// instead of driving the scores negative.
+type RunningTally[V Vote] struct {
+	ScoresTotal      utils.Accumulator
+	Scores           []utils.Accumulator
+	ScoresByStrategy [][]utils.Accumulator
+
+	added    map[[sha256.Size]byte]int
+	validate func(vote Vote) error
+	add      func(t *RunningTally[V], vote V, sign float64)
+}
+
+// NewRunningTally returns an empty tally of choices × strategies scores that
+// checks each vote with validate before counting it with add.
+func NewRunningTally[V Vote](choices int, strategies int, validate func(vote Vote) error, add func(t *RunningTally[V], vote V, sign float64)) RunningTally[V] {
+	return RunningTally[V]{
+		Scores:           make([]utils.Accumulator, choices),
+		ScoresByStrategy: utils.NewAccumulatorMatrix(choices, strategies),
+		added:            map[[sha256.Size]byte]int{},
+		validate:         validate,
+		add:              add,
+	}
+}
+
+// Count adds a vote that is already known to be valid.
+func (t *RunningTally[V]) Count(vote V) {
+	t.added[voteKey(vote)]++
+	t.add(t, vote, 1)
+}
+
+func (t *RunningTally[V]) AddVote(vote Vote) error {
+	v, err := t.check(vote)
+	if err != nil {
+		return err
+	}
+
+	t.Count(v)
+	return nil
+}
+
+func (t *RunningTally[V]) RemoveVote(vote Vote) error {
+	v, err := t.check(vote)
+	if err != nil {
+		return err
+	}
+
+	return t.uncount(v)
+}
+
+func (t *RunningTally[V]) ReplaceVote(oldVote Vote, newVote Vote) error {
+	oldV, err := t.check(oldVote)
+	if err != nil {
+		return err
+	}
+
+	newV, err := t.check(newVote)
+	if err != nil {
+		return err
+	}
+
+	if err := t.uncount(oldV); err != nil {
+		return err
+	}
+	t.Count(newV)
+	return nil
+}
+
+// Merge adds the votes tallied by other into t.
+func (t *RunningTally[V]) Merge(other *RunningTally[V]) {
+	for key, count := range other.added {
+		t.added[key] += count
+	}
+	t.ScoresTotal.Merge(&other.ScoresTotal)
+	for idx := range t.Scores {
+		t.Scores[idx].Merge(&other.Scores[idx])
+		for sIdx := range t.ScoresByStrategy[idx] {
+			t.ScoresByStrategy[idx][sIdx].Merge(&other.ScoresByStrategy[idx][sIdx])
+		}
+	}
+}
+
+func (t *RunningTally[V]) GetScoresTotal() float64 {
+	return t.ScoresTotal.Float64()
+}
+
+func (t *RunningTally[V]) GetScores() []float64 {
+	return utils.AccumulatorsToFloats(t.Scores)
+}
+
+func (t *RunningTally[V]) GetScoresByStrategy() [][]float64 {
+	return utils.AccumulatorMatrixToFloats(t.ScoresByStrategy)
+}
+
+func (t *RunningTally[V]) check(vote Vote) (V, error) {
+	if err := t.validate(vote); err != nil {
+		var zero V
+		return zero, err
+	}
+
+	v, ok := vote.(V)
+	if !ok {
+		return v, NewValidationError(CodeInvalidVoteType, "expected %T, got %T", v, vote)
+	}
+	return v, nil
+}
+
+// uncount takes back a vote that was added and not yet removed.
+func (t *RunningTally[V]) uncount(vote V) error {
+	key := voteKey(vote)
+	switch t.added[key] {
+	case 0:
+		return NewValidationError(CodeVoteNotAdded, "vote was not added to the tally")
+	case 1:
+		delete(t.added, key)
+	default:
+		t.added[key]--
+	}
+
+	t.add(t, vote, -1)
+	return nil
+}
+
+// voteKey identifies a vote by its contents, so an equal vote that was
+// decoded again has the same key.
+func voteKey(vote Vote) [sha256.Size]byte {
+	return sha256.Sum256([]byte(fmt.Sprintf("%T%+v", vote, vote)))
+}
@@ -0,0 +1,172 @@
+package voting
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// TieBreaker picks a single winner among tied 1-based choices.
+type TieBreaker interface {
+	Name() string
+	Break(v VotingSystem, tied []int) int
+}
+
+// TieSettler is implemented by voting types whose own rules pick a winner
+// when their scores do not, such as STAR settling a tied runoff. It returns
+// that winner, or 0, and the name of the rule used.
+type TieSettler interface {
+	SettleTie() (int, string)
+}
+
+var ErrUnknownTieBreaker = errors.New("unknown tie-break policy")
+
+// LowestIndex picks the tied choice listed first.
+type LowestIndex struct{}
+
+// MostVoters picks the tied choice supported by the most valid votes.
+type MostVoters struct{}
+
+// HighestBalance picks the tied choice backed by the largest single valid
+// vote balance.
+type HighestBalance struct{}
+
+// EarliestVote picks the tied choice whose first supporting vote was cast
+// earliest. Votes without a Created timestamp are ignored.
+type EarliestVote struct{}
+
+// Lottery picks a tied choice at random from a source seeded with Seed, so
+// the same seed and tie always give the same winner.
+type Lottery struct {
+	Seed int64
+}
+
+func TieBreakerByName(name string, seed int64) (TieBreaker, error) {
+	switch name {
+	case LowestIndex{}.Name():
+		return LowestIndex{}, nil
+	case MostVoters{}.Name():
+		return MostVoters{}, nil
+	case HighestBalance{}.Name():
+		return HighestBalance{}, nil
+	case EarliestVote{}.Name():
+		return EarliestVote{}, nil
+	case Lottery{}.Name():
+		return Lottery{Seed: seed}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %q", ErrUnknownTieBreaker, name)
+}
+
+func (LowestIndex) Name() string {
+	return "lowest-index"
+}
+
+func (LowestIndex) Break(v VotingSystem, tied []int) int {
+	return lowestChoice(tied)
+}
+
+func (MostVoters) Name() string {
+	return "most-voters"
+}
+
+func (MostVoters) Break(v VotingSystem, tied []int) int {
+	votes := GetValidVotes(v)
+	return breakByKey(tied, func(choice int) (float64, bool) {
+		voters := 0
+		for _, vote := range votes {
+			if vote.Supports(choice) {
+				voters++
+			}
+		}
+		return float64(voters), true
+	}, true)
+}
+
+func (HighestBalance) Name() string {
+	return "highest-balance"
+}
+
+func (HighestBalance) Break(v VotingSystem, tied []int) int {
+	votes := GetValidVotes(v)
+	return breakByKey(tied, func(choice int) (float64, bool) {
+		highest, found := float64(0), false
+		for _, vote := range votes {
+			if !vote.Supports(choice) {
+				continue
+			}
+			if balance := VoteBalance(v, vote); !found || balance > highest {
+				highest, found = balance, true
+			}
+		}
+		return highest, found
+	}, true)
+}
+
+func (EarliestVote) Name() string {
+	return "earliest-vote"
+}
+
+func (EarliestVote) Break(v VotingSystem, tied []int) int {
+	votes := GetValidVotes(v)
+	return breakByKey(tied, func(choice int) (float64, bool) {
+		earliest, found := int64(0), false
+		for _, vote := range votes {
+			created := vote.GetCreated()
+			if created > 0 && vote.Supports(choice) && (!found || created < earliest) {
+				earliest, found = created, true
+			}
+		}
+		return float64(earliest), found
+	}, false)
+}
+
+func (Lottery) Name() string {
+	return "lottery"
+}
+
+func (l Lottery) Break(v VotingSystem, tied []int) int {
+	if len(tied) == 0 {
+		return 0
+	}
+
+	sorted := append([]int{}, tied...)
+	sort.Ints(sorted)
+
+	return sorted[rand.New(rand.NewSource(l.Seed)).Intn(len(sorted))]
+}
+
+// breakByKey picks the tied choice with the highest (or lowest) key. Choices
+// without a key lose to those with one, and remaining ties go to the lowest
+// index.
+func breakByKey(tied []int, key func(choice int) (float64, bool), highest bool) int {
+	sorted := append([]int{}, tied...)
+	sort.Ints(sorted)
+
+	best, bestKey, bestFound := 0, float64(0), false
+	for _, choice := range sorted {
+		choiceKey, found := key(choice)
+		switch {
+		case best == 0:
+		case found && !bestFound:
+		case found && highest && choiceKey > bestKey:
+		case found && !highest && choiceKey < bestKey:
+		default:
+			continue
+		}
+		best, bestKey, bestFound = choice, choiceKey, found
+	}
+
+	return best
+}
+
+func lowestChoice(choices []int) int {
+	lowest := 0
+	for _, choice := range choices {
+		if lowest == 0 || choice < lowest {
+			lowest = choice
+		}
+	}
+	return lowest
+}
@@ -0,0 +1,173 @@
+package voting
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+type ErrorCode string
+
+const (
+	CodeInvalidVote      ErrorCode = "invalid-vote"
+	CodeInvalidVoteType  ErrorCode = "invalid-vote-type"
+	CodeEmptyChoice      ErrorCode = "empty-choice"
+	CodeChoiceOutOfRange ErrorCode = "choice-out-of-range"
+	CodeDuplicateChoice  ErrorCode = "duplicate-choice"
+	CodeInvalidChoiceKey ErrorCode = "invalid-choice-key"
+	CodeNegativeWeight   ErrorCode = "negative-weight"
+	CodeZeroWeight       ErrorCode = "zero-weight"
+	CodeWeightOutOfRange ErrorCode = "weight-out-of-range"
+	CodeRatingOutOfRange ErrorCode = "rating-out-of-range"
+	CodeInvalidBalance   ErrorCode = "invalid-balance"
+	CodeNegativeBalance  ErrorCode = "negative-balance"
+	CodeInvalidScore     ErrorCode = "invalid-score"
+	CodeNegativeScore    ErrorCode = "negative-score"
+	CodeScoresLength     ErrorCode = "scores-length-mismatch"
+	CodeScoresSum        ErrorCode = "scores-sum-mismatch"
+	CodeInvalidStrategy  ErrorCode = "invalid-strategy"
+	CodeVoteNotAdded     ErrorCode = "vote-not-added"
+)
+
+// ValidationError explains why a vote was rejected. Code is stable and
+// meant for machines; Message is meant for the voter.
+type ValidationError struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+func NewValidationError(code ErrorCode, format string, args ...interface{}) *ValidationError {
+	return &ValidationError{
+		Code:    code,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// Is reports whether target is a ValidationError with the same code, so
+// errors.Is(err, &ValidationError{Code: CodeDuplicateChoice}) works.
+func (e *ValidationError) Is(target error) bool {
+	t, ok := target.(*ValidationError)
+	return ok && t.Code == e.Code
+}
+
+func ErrorCodeOf(err error) ErrorCode {
+	if err == nil {
+		return ""
+	}
+
+	var validationError *ValidationError
+	if errors.As(err, &validationError) {
+		return validationError.Code
+	}
+
+	return CodeInvalidVote
+}
+
+// ValidatePower rejects a balance or scores that are NaN, infinite or
+// negative, and scores that do not have one entry per strategy.
+func ValidatePower(balance float64, scores []float64, strategies int) error {
+	if math.IsNaN(balance) || math.IsInf(balance, 0) {
+		return NewValidationError(CodeInvalidBalance, "balance %v is not a finite number", balance)
+	}
+
+	if balance < 0 {
+		return NewValidationError(CodeNegativeBalance, "balance %v is negative", balance)
+	}
+
+	if len(scores) != strategies {
+		return NewValidationError(CodeScoresLength, "%d scores for %d strategies", len(scores), strategies)
+	}
+
+	for idx, score := range scores {
+		if math.IsNaN(score) || math.IsInf(score, 0) {
+			return NewValidationError(CodeInvalidScore, "score %d is %v, not a finite number", idx, score)
+		}
+
+		if score < 0 {
+			return NewValidationError(CodeNegativeScore, "score %d is %v, which is negative", idx, score)
+		}
+	}
+
+	return nil
+}
+
+// ValidateScoresSum rejects a vote whose scores do not add up to its
+// balance. tolerance is relative to the balance, or absolute for balances
+// below 1. Votes without scores are not checked.
+func ValidateScoresSum(vote Vote, tolerance float64) error {
+	scores := vote.GetScores()
+	if len(scores) == 0 {
+		return nil
+	}
+
+	sum := float64(0)
+	for _, score := range scores {
+		sum = sum + score
+	}
+
+	balance := vote.GetBalance()
+	if math.Abs(sum-balance) > tolerance*math.Max(math.Abs(balance), 1) {
+		return NewValidationError(CodeScoresSum, "scores add up to %v, not balance %v", sum, balance)
+	}
+
+	return nil
+}
+
+type InvalidVote struct {
+	Index int              `json:"index"`
+	Vote  Vote             `json:"vote"`
+	Error *ValidationError `json:"error"`
+}
+
+type ValidationReport struct {
+	ValidVotes   int           `json:"validVotes"`
+	InvalidVotes []InvalidVote `json:"invalidVotes"`
+}
+
+// Validate checks every vote and lists the rejected ones, by their index in
+// GetVotes, with the reason each was rejected.
+func Validate(v VotingSystem) ValidationReport {
+	return validate(v, v.ValidateVote)
+}
+
+// ValidateStrict is Validate with ValidateScoresSum applied to the votes
+// that are otherwise valid.
+func ValidateStrict(v VotingSystem, tolerance float64) ValidationReport {
+	return validate(v, func(vote Vote) error {
+		if err := v.ValidateVote(vote); err != nil {
+			return err
+		}
+		return ValidateScoresSum(vote, tolerance)
+	})
+}
+
+func validate(v VotingSystem, validateVote func(vote Vote) error) ValidationReport {
+	report := ValidationReport{
+		InvalidVotes: []InvalidVote{},
+	}
+
+	for idx, vote := range v.GetVotes() {
+		err := validateVote(vote)
+		if err == nil {
+			report.ValidVotes++
+			continue
+		}
+
+		var validationError *ValidationError
+		if !errors.As(err, &validationError) {
+			validationError = NewValidationError(CodeInvalidVote, "%s", err)
+		}
+
+		report.InvalidVotes = append(report.InvalidVotes, InvalidVote{
+			Index: idx,
+			Vote:  vote,
+			Error: validationError,
+		})
+	}
+
+	return report
+}
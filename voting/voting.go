@@ -0,0 +1,63 @@
+package voting
+
+import (
+	"github.com/This-Is-Prince/votingSystemGo/utils"
+)
+
+// Vote is the behaviour shared by the vote types of every voting package.
+type Vote interface {
+	GetChoice() interface{}
+	GetBalance() float64
+	GetScores() []float64
+	GetVoter() string
+	GetCreated() int64
+	Supports(choice int) bool
+}
+
+// VotingSystem is implemented by every voting type, so tallying and
+// reporting code can be written once and work for any of them.
+type VotingSystem interface {
+	GetChoices() []string
+	GetStrategies() []Strategy
+	GetVotes() []Vote
+	Deduplicate(policy DedupPolicy) []Vote
+	IsValidVote(vote Vote) bool
+	ValidateVote(vote Vote) error
+	GetScoresTotal() float64
+	GetScores() []float64
+	GetScoresByStrategy() [][]float64
+}
+
+// PercentageSystem is implemented by voting types whose scores are not
+// shares of GetScoresTotal, such as counts of pairwise wins. NewResult then
+// reports their own percentages instead of dividing by the total.
+type PercentageSystem interface {
+	GetPercentages() []float64
+}
+
+func ToVotes[V Vote](votes []V) []Vote {
+	converted := []Vote{}
+	for _, vote := range votes {
+		converted = append(converted, vote)
+	}
+	return converted
+}
+
+func GetValidVotes(v VotingSystem) []Vote {
+	return utils.Filter(v.GetVotes(), func(vote Vote) bool {
+		return v.IsValidVote(vote)
+	})
+}
+
+// Tallier keeps running scores that are updated one vote at a time. A vote
+// that fails validation is rejected with its error and leaves the scores
+// unchanged. Only votes that were added may be removed or replaced; any
+// other is rejected with CodeVoteNotAdded.
+type Tallier interface {
+	AddVote(vote Vote) error
+	RemoveVote(vote Vote) error
+	ReplaceVote(oldVote Vote, newVote Vote) error
+	GetScoresTotal() float64
+	GetScores() []float64
+	GetScoresByStrategy() [][]float64
+}
@@ -0,0 +1,1201 @@
+package voting_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/This-Is-Prince/votingSystemGo/approval"
+	"github.com/This-Is-Prince/votingSystemGo/quadratic"
+	"github.com/This-Is-Prince/votingSystemGo/rankedChoice"
+	"github.com/This-Is-Prince/votingSystemGo/score"
+	"github.com/This-Is-Prince/votingSystemGo/singleChoice"
+	"github.com/This-Is-Prince/votingSystemGo/utils"
+	"github.com/This-Is-Prince/votingSystemGo/voting"
+	"github.com/This-Is-Prince/votingSystemGo/weighted"
+)
+
+func TestVotingSystem(t *testing.T) {
+	choices := []string{"First", "Second", "Third"}
+	strategies := []voting.Strategy{{Name: "erc20-balance-of"}, {Name: "delegation"}}
+	votingSystems := map[string]voting.VotingSystem{
+		"single-choice": &singleChoice.SingleChoiceVoting{
+			Choices:    choices,
+			Strategies: strategies,
+			Votes: []singleChoice.SingleChoiceVote{
+				{Choice: 1, Balance: 3, Scores: []float64{1, 2}},
+				{Choice: 4, Balance: 5, Scores: []float64{2, 3}},
+			},
+		},
+		"approval": &approval.ApprovalVoting{
+			Choices:    choices,
+			Strategies: strategies,
+			Votes: []approval.ApprovalVote{
+				{Choice: []int{1, 2}, Balance: 3, Scores: []float64{1, 2}},
+				{Choice: []int{1, 1}, Balance: 5, Scores: []float64{2, 3}},
+			},
+		},
+		"weighted": &weighted.WeightedVoting{
+			Choices:    choices,
+			Strategies: strategies,
+			Votes: []weighted.WeightedVote{
+				{Choice: weighted.WeightedChoice{"1": 1}, Balance: 3, Scores: []float64{1, 2}},
+				{Choice: weighted.WeightedChoice{"x": 1}, Balance: 5, Scores: []float64{2, 3}},
+			},
+		},
+		"quadratic": &quadratic.QuadraticVoting{
+			Choices:    choices,
+			Strategies: strategies,
+			Votes: []quadratic.QuadraticVote{
+				{Choice: quadratic.QuadraticChoice{"1": 1}, Balance: 3, Scores: []float64{1, 2}},
+				{Choice: quadratic.QuadraticChoice{}, Balance: 5, Scores: []float64{2, 3}},
+			},
+		},
+	}
+
+	for name, v := range votingSystems {
+		if len(v.GetChoices()) != len(choices) {
+			t.Errorf("%s: expected %d choices, got %d", name, len(choices), len(v.GetChoices()))
+		}
+
+		if len(v.GetStrategies()) != len(strategies) {
+			t.Errorf("%s: expected %d strategies, got %d", name, len(strategies), len(v.GetStrategies()))
+		}
+
+		if len(v.GetVotes()) != 2 {
+			t.Errorf("%s: expected %d votes, got %d", name, 2, len(v.GetVotes()))
+		}
+
+		validVotes := voting.GetValidVotes(v)
+		if len(validVotes) != 1 {
+			t.Fatalf("%s: expected %d valid votes, got %d", name, 1, len(validVotes))
+		}
+
+		if !utils.FloatEqual(validVotes[0].GetBalance(), 3) {
+			t.Errorf("%s: expected valid vote balance %f, got %f", name, float64(3), validVotes[0].GetBalance())
+		}
+
+		scores := v.GetScores()
+		if !utils.FloatEqual(scores[0], 3) {
+			t.Errorf("%s: expected score %f for choice %s, got %f", name, float64(3), choices[0], scores[0])
+		}
+	}
+}
+
+func TestResult(t *testing.T) {
+	v := &singleChoice.SingleChoiceVoting{
+		Choices:    []string{"First", "Second", "Third"},
+		Strategies: []voting.Strategy{{Name: "erc20-balance-of"}},
+		Votes: []singleChoice.SingleChoiceVote{
+			{Choice: 1, Balance: 4, Scores: []float64{4}},
+			{Choice: 2, Balance: 2, Scores: []float64{2}},
+			{Choice: 3, Balance: 4, Scores: []float64{4}},
+			{Choice: 5, Balance: 1, Scores: []float64{1}},
+		},
+	}
+
+	result := voting.NewResult(v)
+	if result.ValidVotes != 3 || result.InvalidVotes != 1 {
+		t.Errorf("Expected %d valid and %d invalid votes, got %d and %d", 3, 1, result.ValidVotes, result.InvalidVotes)
+	}
+
+	if !utils.FloatEqual(result.ScoresTotal, 10) {
+		t.Errorf("Expected scores total to be %f, got %f", float64(10), result.ScoresTotal)
+	}
+
+	expectedPercentages := []float64{0.4, 0.2, 0.4}
+	for i, percentage := range result.Percentages {
+		if !utils.FloatEqual(percentage, expectedPercentages[i]) {
+			t.Errorf("Expected percentage %f, got %f", expectedPercentages[i], percentage)
+		}
+	}
+
+	if !result.Tie || len(result.Winners) != 2 || result.Winners[0] != 1 || result.Winners[1] != 3 {
+		t.Errorf("Expected a tie between choices 1 and 3, got %v", result.Winners)
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("Expected result to marshal, got %v", err)
+	}
+
+	expectedJSON := `{"scores":[4,2,4],"scoresByStrategy":[[4],[2],[4]],"strategies":["erc20-balance-of"],"scoresTotal":10,"percentages":[0.4,0.2,0.4],"winners":[1,3],"tie":true,"winner":0,"validVotes":3,"invalidVotes":1}`
+	if string(data) != expectedJSON {
+		t.Errorf("Expected %s, got %s", expectedJSON, data)
+	}
+
+	empty := voting.NewResult(&singleChoice.SingleChoiceVoting{Choices: []string{"First"}})
+	if len(empty.Winners) != 0 || empty.Tie {
+		t.Errorf("Expected no winners without votes, got %v", empty.Winners)
+	}
+}
+
+func TestTieBreakers(t *testing.T) {
+	v := &approval.ApprovalVoting{
+		Choices:    []string{"First", "Second", "Third"},
+		Strategies: []voting.Strategy{{Name: "erc20-balance-of"}},
+		Votes: []approval.ApprovalVote{
+			{Choice: []int{1}, Balance: 6, Scores: []float64{6}, Created: 300},
+			{Choice: []int{2, 3}, Balance: 2, Scores: []float64{2}, Created: 200},
+			{Choice: []int{2, 3}, Balance: 3, Scores: []float64{3}, Created: 400},
+			{Choice: []int{3}, Balance: 1, Scores: []float64{1}},
+			{Choice: []int{3}, Balance: 0, Scores: []float64{0}},
+			{Choice: []int{2}, Balance: 1, Scores: []float64{1}, Created: 100},
+			{Choice: []int{1, 4}, Balance: 1, Scores: []float64{1}, Created: 50},
+		},
+	}
+
+	result := voting.NewResult(v)
+	if !result.Tie || len(result.Winners) != 3 || result.Winner != 0 {
+		t.Fatalf("Expected an unresolved three-way tie, got winners %v and winner %d", result.Winners, result.Winner)
+	}
+
+	expectedWinners := map[string]int{
+		"lowest-index":    1,
+		"most-voters":     3,
+		"highest-balance": 1,
+		"earliest-vote":   2,
+	}
+
+	for name, expectedWinner := range expectedWinners {
+		tieBreaker, err := voting.TieBreakerByName(name, 0)
+		if err != nil {
+			t.Fatalf("Expected tie breaker %s, got %v", name, err)
+		}
+
+		result := voting.NewResultWithTieBreaker(v, tieBreaker)
+		if result.Winner != expectedWinner {
+			t.Errorf("Expected %s to pick %d among %v, got %d", name, expectedWinner, result.Winners, result.Winner)
+		}
+		if result.TieBreak != name {
+			t.Errorf("Expected tie break %s to be recorded, got %s", name, result.TieBreak)
+		}
+	}
+
+	lottery := voting.Lottery{Seed: 42}
+	winner := lottery.Break(v, []int{3, 1, 2})
+	for i := 0; i < 10; i++ {
+		if lottery.Break(v, []int{1, 2, 3}) != winner {
+			t.Fatalf("Expected lottery with the same seed to be deterministic")
+		}
+	}
+
+	if _, err := voting.TieBreakerByName("coin-toss", 0); !errors.Is(err, voting.ErrUnknownTieBreaker) {
+		t.Errorf("Expected ErrUnknownTieBreaker, got %v", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	choices := []string{"First", "Second", "Third"}
+	tests := []struct {
+		name string
+		v    voting.VotingSystem
+		code voting.ErrorCode
+	}{
+		{
+			"single-choice out of range",
+			&singleChoice.SingleChoiceVoting{Choices: choices, Votes: []singleChoice.SingleChoiceVote{{Choice: 4}}},
+			voting.CodeChoiceOutOfRange,
+		},
+		{
+			"approval out of range",
+			&approval.ApprovalVoting{Choices: choices, Votes: []approval.ApprovalVote{{Choice: []int{1, 0}}}},
+			voting.CodeChoiceOutOfRange,
+		},
+		{
+			"approval duplicate",
+			&approval.ApprovalVoting{Choices: choices, Votes: []approval.ApprovalVote{{Choice: []int{2, 2}}}},
+			voting.CodeDuplicateChoice,
+		},
+		{
+			"weighted empty",
+			&weighted.WeightedVoting{Choices: choices, Votes: []weighted.WeightedVote{{}}},
+			voting.CodeEmptyChoice,
+		},
+		{
+			"weighted non-numeric key",
+			&weighted.WeightedVoting{Choices: choices, Votes: []weighted.WeightedVote{{Choice: weighted.WeightedChoice{"first": 1}}}},
+			voting.CodeInvalidChoiceKey,
+		},
+		{
+			"weighted negative weight",
+			&weighted.WeightedVoting{Choices: choices, Votes: []weighted.WeightedVote{{Choice: weighted.WeightedChoice{"1": -1}}}},
+			voting.CodeNegativeWeight,
+		},
+		{
+			"weighted key out of range",
+			&weighted.WeightedVoting{Choices: choices, Votes: []weighted.WeightedVote{{Choice: weighted.WeightedChoice{"4": 1}}}},
+			voting.CodeChoiceOutOfRange,
+		},
+		{
+			"quadratic zero weight",
+			&quadratic.QuadraticVoting{Choices: choices, Votes: []quadratic.QuadraticVote{{Choice: quadratic.QuadraticChoice{"1": 0}}}},
+			voting.CodeZeroWeight,
+		},
+		{
+			"quadratic weight out of range",
+			&quadratic.QuadraticVoting{Choices: choices, Votes: []quadratic.QuadraticVote{{Choice: quadratic.QuadraticChoice{"1": 4}}}},
+			voting.CodeWeightOutOfRange,
+		},
+		{
+			"score rating out of range",
+			&score.ScoreVoting{Choices: choices, Votes: []score.ScoreVote{{Choice: score.ScoreChoice{"1": 6}}}},
+			voting.CodeRatingOutOfRange,
+		},
+	}
+
+	for _, test := range tests {
+		report := voting.Validate(test.v)
+		if report.ValidVotes != 0 || len(report.InvalidVotes) != 1 {
+			t.Fatalf("%s: expected one invalid vote, got %+v", test.name, report)
+		}
+
+		err := report.InvalidVotes[0].Error
+		if err.Code != test.code {
+			t.Errorf("%s: expected code %s, got %s", test.name, test.code, err.Code)
+		}
+		if err.Message == "" {
+			t.Errorf("%s: expected an error message", test.name)
+		}
+	}
+
+	v := &singleChoice.SingleChoiceVoting{
+		Choices: choices,
+		Votes:   []singleChoice.SingleChoiceVote{{Choice: 1}, {Choice: 0}, {Choice: 3}},
+	}
+
+	report := voting.Validate(v)
+	if report.ValidVotes != 2 || len(report.InvalidVotes) != 1 || report.InvalidVotes[0].Index != 1 {
+		t.Errorf("Expected the vote at index 1 to be invalid, got %+v", report)
+	}
+
+	err := v.ValidateVote(approval.ApprovalVote{Choice: []int{1}})
+	if !errors.Is(err, &voting.ValidationError{Code: voting.CodeInvalidVoteType}) {
+		t.Errorf("Expected code %s, got %v", voting.CodeInvalidVoteType, err)
+	}
+
+	if code := voting.ErrorCodeOf(v.ValidateVote(v.Votes[1])); code != voting.CodeChoiceOutOfRange {
+		t.Errorf("Expected code %s, got %s", voting.CodeChoiceOutOfRange, code)
+	}
+
+	data, err := json.Marshal(report.InvalidVotes[0].Error)
+	if err != nil || string(data) != `{"code":"choice-out-of-range","message":"choice 0 is not between 1 and 3"}` {
+		t.Errorf("Unexpected error JSON %s (%v)", data, err)
+	}
+}
+
+func TestValidatePower(t *testing.T) {
+	choices := []string{"First", "Second"}
+	strategies := []voting.Strategy{{Name: "erc20-balance-of"}, {Name: "delegation"}}
+	tests := []struct {
+		name string
+		vote singleChoice.SingleChoiceVote
+		code voting.ErrorCode
+	}{
+		{"NaN balance", singleChoice.SingleChoiceVote{Choice: 1, Balance: math.NaN(), Scores: []float64{1, 1}}, voting.CodeInvalidBalance},
+		{"infinite balance", singleChoice.SingleChoiceVote{Choice: 1, Balance: math.Inf(1), Scores: []float64{1, 1}}, voting.CodeInvalidBalance},
+		{"negative balance", singleChoice.SingleChoiceVote{Choice: 1, Balance: -2, Scores: []float64{1, 1}}, voting.CodeNegativeBalance},
+		{"too many scores", singleChoice.SingleChoiceVote{Choice: 1, Balance: 3, Scores: []float64{1, 1, 1}}, voting.CodeScoresLength},
+		{"too few scores", singleChoice.SingleChoiceVote{Choice: 1, Balance: 3, Scores: []float64{3}}, voting.CodeScoresLength},
+		{"NaN score", singleChoice.SingleChoiceVote{Choice: 1, Balance: 3, Scores: []float64{1, math.NaN()}}, voting.CodeInvalidScore},
+		{"negative score", singleChoice.SingleChoiceVote{Choice: 1, Balance: 3, Scores: []float64{4, -1}}, voting.CodeNegativeScore},
+	}
+
+	for _, test := range tests {
+		v := &singleChoice.SingleChoiceVoting{
+			Choices:    choices,
+			Strategies: strategies,
+			Votes: []singleChoice.SingleChoiceVote{
+				{Choice: 2, Balance: 2, Scores: []float64{1, 1}},
+				test.vote,
+			},
+		}
+
+		if code := voting.ErrorCodeOf(v.ValidateVote(test.vote)); code != test.code {
+			t.Errorf("%s: expected code %s, got %s", test.name, test.code, code)
+		}
+
+		if scoresTotal := v.GetScoresTotal(); !utils.FloatEqual(scoresTotal, 2) {
+			t.Errorf("%s: expected scores total to be %f, got %f", test.name, float64(2), scoresTotal)
+		}
+
+		if scores := v.GetScores(); !utils.FloatEqual(scores[0], 0) {
+			t.Errorf("%s: expected the malformed vote to be ignored, got %f", test.name, scores[0])
+		}
+
+		if scoresByStrategy := v.GetScoresByStrategy(); !utils.FloatEqual(scoresByStrategy[0][0], 0) {
+			t.Errorf("%s: expected the malformed vote to be ignored, got %v", test.name, scoresByStrategy)
+		}
+	}
+
+	v := &weighted.WeightedVoting{
+		Choices:    choices,
+		Strategies: strategies,
+		Votes: []weighted.WeightedVote{
+			{Choice: weighted.WeightedChoice{"1": 1}, Balance: 3, Scores: []float64{1, 2.0000000001}},
+			{Choice: weighted.WeightedChoice{"1": 1}, Balance: 3, Scores: []float64{1, 1}},
+		},
+	}
+
+	if report := voting.Validate(v); len(report.InvalidVotes) != 0 {
+		t.Errorf("Expected scores sums not to be checked by Validate, got %+v", report)
+	}
+
+	report := voting.ValidateStrict(v, 0.000001)
+	if report.ValidVotes != 1 || len(report.InvalidVotes) != 1 || report.InvalidVotes[0].Error.Code != voting.CodeScoresSum {
+		t.Errorf("Expected the second vote to fail the scores sum check, got %+v", report)
+	}
+}
+
+func TestDeduplicate(t *testing.T) {
+	newVoting := func() *singleChoice.SingleChoiceVoting {
+		return &singleChoice.SingleChoiceVoting{
+			Choices: []string{"First", "Second", "Third"},
+			Votes: []singleChoice.SingleChoiceVote{
+				{Voter: "0xAbC", Choice: 1, Balance: 5, Created: 200},
+				{Voter: "0xdef", Choice: 2, Balance: 2, Created: 100},
+				{Choice: 3, Balance: 1, Created: 150},
+				{Voter: "0xabc", Choice: 3, Balance: 5, Created: 300},
+				{Voter: "0xABC", Choice: 2, Balance: 5, Created: 50},
+				{Choice: 3, Balance: 1, Created: 150},
+			},
+		}
+	}
+
+	v := newVoting()
+	superseded := v.Deduplicate(voting.LatestVote)
+	if len(v.Votes) != 4 || len(superseded) != 2 {
+		t.Fatalf("Expected %d kept and %d superseded votes, got %d and %d", 4, 2, len(v.Votes), len(superseded))
+	}
+
+	expectedScores := []float64{0, 2, 7}
+	for i, score := range v.GetScores() {
+		if !utils.FloatEqual(score, expectedScores[i]) {
+			t.Errorf("Expected score %f for choice %d, got %f", expectedScores[i], i+1, score)
+		}
+	}
+
+	if superseded[0].GetCreated() != 200 || superseded[1].GetCreated() != 50 {
+		t.Errorf("Expected the votes created at 200 and 50 to be superseded, got %v", superseded)
+	}
+
+	v = newVoting()
+	v.Deduplicate(voting.FirstVote)
+	expectedScores = []float64{0, 7, 2}
+	for i, score := range v.GetScores() {
+		if !utils.FloatEqual(score, expectedScores[i]) {
+			t.Errorf("Expected score %f for choice %d, got %f", expectedScores[i], i+1, score)
+		}
+	}
+
+	votes := []approval.ApprovalVote{
+		{Voter: "0x1", Choice: []int{1}, Created: 100},
+		{Voter: "0x1", Choice: []int{2}, Created: 100},
+	}
+	if kept, _ := voting.Deduplicate(votes, voting.LatestVote); kept[0].Choice[0] != 2 {
+		t.Errorf("Expected the last listed vote to win a timestamp tie, got %v", kept)
+	}
+	if kept, _ := voting.Deduplicate(votes, voting.FirstVote); kept[0].Choice[0] != 1 {
+		t.Errorf("Expected the first listed vote to win a timestamp tie, got %v", kept)
+	}
+}
+
+func TestExactResult(t *testing.T) {
+	choices := []string{"First", "Second", "Third"}
+	strategies := []voting.Strategy{{Name: "erc20-balance-of"}, {Name: "delegation"}}
+	votingSystems := map[string]voting.ExactVotingSystem{
+		"single-choice": &singleChoice.SingleChoiceVoting{
+			Choices:    choices,
+			Strategies: strategies,
+			Votes: []singleChoice.SingleChoiceVote{
+				{Choice: 1, Balance: 0.1, Scores: []float64{0.05, 0.05}},
+				{Choice: 1, Balance: 0.2, Scores: []float64{0.1, 0.1}},
+				{Choice: 2, Balance: 0.3, Scores: []float64{0.1, 0.2}},
+			},
+		},
+		"approval": &approval.ApprovalVoting{
+			Choices:    choices,
+			Strategies: strategies,
+			Votes: []approval.ApprovalVote{
+				{Choice: []int{1, 3}, Balance: 0.1, Scores: []float64{0.05, 0.05}},
+				{Choice: []int{1}, Balance: 0.2, Scores: []float64{0.1, 0.1}},
+				{Choice: []int{2}, Balance: 0.3, Scores: []float64{0.1, 0.2}},
+			},
+		},
+		"weighted": &weighted.WeightedVoting{
+			Choices:    choices,
+			Strategies: strategies,
+			Votes: []weighted.WeightedVote{
+				{Choice: weighted.WeightedChoice{"1": 1, "2": 2}, Balance: 0.1, Scores: []float64{0.05, 0.05}},
+				{Choice: weighted.WeightedChoice{"3": 3}, Balance: 0.2, Scores: []float64{0.1, 0.1}},
+			},
+		},
+		"quadratic": &quadratic.QuadraticVoting{
+			Choices:    choices,
+			Strategies: strategies,
+			Votes: []quadratic.QuadraticVote{
+				{Choice: quadratic.QuadraticChoice{"1": 1, "2": 2}, Balance: 0.1, Scores: []float64{0.05, 0.05}},
+				{Choice: quadratic.QuadraticChoice{"3": 3}, Balance: 0.2, Scores: []float64{0.1, 0.1}},
+			},
+		},
+		"ranked-choice": &rankedChoice.RankedChoiceVoting{
+			Choices:    choices,
+			Strategies: strategies,
+			Votes: []rankedChoice.RankedChoiceVote{
+				{Choice: []int{3, 1}, Balance: 0.1, Scores: []float64{0.05, 0.05}},
+				{Choice: []int{1}, Balance: 0.2, Scores: []float64{0.1, 0.1}},
+				{Choice: []int{2}, Balance: 0.3, Scores: []float64{0.1, 0.2}},
+			},
+		},
+	}
+
+	for name, v := range votingSystems {
+		exactTotal, _ := v.GetScoresTotalExact().Float64()
+		if !utils.FloatEqual(exactTotal, v.GetScoresTotal()) {
+			t.Errorf("%s: expected exact total %f, got %f", name, v.GetScoresTotal(), exactTotal)
+		}
+
+		scores := v.GetScores()
+		for i, score := range v.GetScoresExact() {
+			if exactScore, _ := score.Float64(); !utils.FloatEqual(exactScore, scores[i]) {
+				t.Errorf("%s: expected exact score %f for choice %d, got %f", name, scores[i], i+1, exactScore)
+			}
+		}
+
+		scoresByStrategy := v.GetScoresByStrategy()
+		for i, exactScores := range v.GetScoresByStrategyExact() {
+			for j, score := range exactScores {
+				if exactScore, _ := score.Float64(); !utils.FloatEqual(exactScore, scoresByStrategy[i][j]) {
+					t.Errorf("%s: expected exact score %f, got %f", name, scoresByStrategy[i][j], exactScore)
+				}
+			}
+		}
+	}
+
+	v := &singleChoice.SingleChoiceVoting{}
+	err := json.Unmarshal([]byte(`{
+		"choices": ["First", "Second"],
+		"strategies": [1],
+		"votes": [
+			{"choice": 1, "balance": 0.1, "scores": [0.1], "exactBalance": "0.1", "exactScores": ["0.1"]},
+			{"choice": 1, "balance": 0.2, "scores": [0.2], "exactBalance": "0.2", "exactScores": ["0.2"]},
+			{"choice": 2, "balance": 1, "scores": [1], "exactBalance": "2/3", "exactScores": ["2/3"]}
+		]
+	}`), v)
+	if err != nil {
+		t.Fatalf("Expected proposal to decode, got %v", err)
+	}
+
+	result := voting.NewExactResult(v, 18, utils.RoundHalfUp)
+	expected := voting.ExactResult{
+		Scores:           []string{"0.300000000000000000", "0.666666666666666667"},
+		ScoresByStrategy: [][]string{{"0.300000000000000000"}, {"0.666666666666666667"}},
+		Strategies:       []string{"1"},
+		ScoresTotal:      "0.966666666666666667",
+		Decimals:         18,
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, result)
+	}
+
+	v.Votes[0].ExactBalance = big.NewRat(-1, 10)
+	if code := voting.ErrorCodeOf(v.ValidateVote(v.Votes[0])); code != voting.CodeNegativeBalance {
+		t.Errorf("Expected code %s, got %s", voting.CodeNegativeBalance, code)
+	}
+}
+
+func TestUnits(t *testing.T) {
+	v := &approval.ApprovalVoting{}
+	err := json.Unmarshal([]byte(`{
+		"choices": ["First", "Second"],
+		"strategies": [1, 2],
+		"decimals": 18,
+		"votes": [
+			{"choice": [1, 2], "balanceUnits": 123456789012345678901234567, "scoresUnits": [123456789012345678901234566, 1]},
+			{"choice": [1], "balanceUnits": 1, "scoresUnits": [0, 1]},
+			{"choice": [2], "balance": 0.5, "scores": [0.25, 0.25]}
+		]
+	}`), v)
+	if err != nil {
+		t.Fatalf("Expected proposal to decode, got %v", err)
+	}
+
+	expectedScores := []string{"123456789012345678901234568", "123456789512345678901234567"}
+	for i, score := range v.GetScoresUnits() {
+		if score.String() != expectedScores[i] {
+			t.Errorf("Expected score %s for choice %d, got %s", expectedScores[i], i+1, score)
+		}
+	}
+
+	if total := v.GetScoresTotalUnits().String(); total != "123456789512345678901234568" {
+		t.Errorf("Expected scores total %s, got %s", "123456789512345678901234568", total)
+	}
+
+	expectedScoresByStrategy := [][]string{
+		{"123456789012345678901234566", "2"},
+		{"123456789262345678901234566", "250000000000000001"},
+	}
+	for i, scores := range v.GetScoresByStrategyUnits() {
+		for j, score := range scores {
+			if score.String() != expectedScoresByStrategy[i][j] {
+				t.Errorf("Expected score %s, got %s", expectedScoresByStrategy[i][j], score)
+			}
+		}
+	}
+
+	result := voting.NewExactResult(v, 18, utils.RoundDown)
+	if result.ScoresTotal != "123456789.512345678901234568" {
+		t.Errorf("Expected scores total %s, got %s", "123456789.512345678901234568", result.ScoresTotal)
+	}
+
+	w := &weighted.WeightedVoting{
+		Choices:    []string{"First", "Second", "Third"},
+		Strategies: []voting.Strategy{{Name: "erc20-balance-of"}},
+		Decimals:   18,
+		Votes: []weighted.WeightedVote{
+			{
+				Choice:       weighted.WeightedChoice{"1": 1, "2": 2},
+				BalanceUnits: big.NewInt(3000000000000000001),
+				ScoresUnits:  []*big.Int{big.NewInt(3000000000000000001)},
+			},
+		},
+	}
+
+	weightedResult := voting.NewExactResult(w, 18, utils.RoundHalfUp)
+	if weightedResult.Scores[0] != "1.000000000000000000" || weightedResult.Scores[1] != "2.000000000000000001" {
+		t.Errorf("Expected exact weighted scores, got %v", weightedResult.Scores)
+	}
+
+	w.Votes[0].ScoresUnits = []*big.Int{big.NewInt(-1)}
+	if code := voting.ErrorCodeOf(w.ValidateVote(w.Votes[0])); code != voting.CodeNegativeScore {
+		t.Errorf("Expected code %s, got %s", voting.CodeNegativeScore, code)
+	}
+
+	// The float tally reads votes with only base units or exact amounts
+	// like the exact tally does.
+	s := &singleChoice.SingleChoiceVoting{
+		Choices:    []string{"First", "Second"},
+		Strategies: []voting.Strategy{{Name: "erc20-balance-of"}},
+		Decimals:   18,
+		Votes: []singleChoice.SingleChoiceVote{
+			{Choice: 1, BalanceUnits: big.NewInt(2000000000000000000), ScoresUnits: []*big.Int{big.NewInt(2000000000000000000)}},
+			{Choice: 2, ExactBalance: big.NewRat(3, 2), ExactScores: []*big.Rat{big.NewRat(3, 2)}},
+			{Choice: 2, BalanceUnits: big.NewInt(1000000000000000000), ScoresUnits: []*big.Int{big.NewInt(1000000000000000000)}},
+		},
+	}
+	floatResult := voting.NewResult(s)
+	if !reflect.DeepEqual(floatResult.Scores, []float64{2, 2.5}) || floatResult.Winner != 2 {
+		t.Errorf("Expected scores %v won by choice 2, got %+v", []float64{2, 2.5}, floatResult)
+	}
+	if !reflect.DeepEqual(floatResult.ScoresByStrategy, [][]float64{{2}, {2.5}}) {
+		t.Errorf("Expected scores by strategy %v, got %v", [][]float64{{2}, {2.5}}, floatResult.ScoresByStrategy)
+	}
+
+	s.Votes[2].BalanceUnits = big.NewInt(500000000000000000)
+	if winner := (voting.HighestBalance{}).Break(s, []int{1, 2}); winner != 1 {
+		t.Errorf("Expected the 2-token vote to break the tie for choice 1, got %d", winner)
+	}
+}
+
+func TestTallier(t *testing.T) {
+	type tallierSystem interface {
+		voting.VotingSystem
+		NewTallier() voting.Tallier
+	}
+
+	choices := []string{"First", "Second", "Third"}
+	strategies := []voting.Strategy{{Name: "erc20-balance-of"}, {Name: "delegation"}}
+
+	systems := map[string]struct {
+		full    func(votes []voting.Vote) tallierSystem
+		votes   []voting.Vote
+		replace voting.Vote
+		invalid voting.Vote
+	}{
+		"single-choice": {
+			full: func(votes []voting.Vote) tallierSystem {
+				v := &singleChoice.SingleChoiceVoting{Choices: choices, Strategies: strategies}
+				for _, vote := range votes {
+					v.Votes = append(v.Votes, vote.(singleChoice.SingleChoiceVote))
+				}
+				return v
+			},
+			votes: []voting.Vote{
+				singleChoice.SingleChoiceVote{Choice: 1, Balance: 0.1, Scores: []float64{0.03, 0.07}},
+				singleChoice.SingleChoiceVote{Choice: 2, Balance: 0.2, Scores: []float64{0.1, 0.1}},
+				singleChoice.SingleChoiceVote{Choice: 1, Balance: 0.3, Scores: []float64{0.2, 0.1}},
+			},
+			replace: singleChoice.SingleChoiceVote{Choice: 3, Balance: 0.7, Scores: []float64{0.35, 0.35}},
+			invalid: singleChoice.SingleChoiceVote{Choice: 4, Balance: 1, Scores: []float64{0.5, 0.5}},
+		},
+		"approval": {
+			full: func(votes []voting.Vote) tallierSystem {
+				v := &approval.ApprovalVoting{Choices: choices, Strategies: strategies}
+				for _, vote := range votes {
+					v.Votes = append(v.Votes, vote.(approval.ApprovalVote))
+				}
+				return v
+			},
+			votes: []voting.Vote{
+				approval.ApprovalVote{Choice: []int{1, 2}, Balance: 0.1, Scores: []float64{0.03, 0.07}},
+				approval.ApprovalVote{Choice: []int{2}, Balance: 0.2, Scores: []float64{0.1, 0.1}},
+				approval.ApprovalVote{Choice: []int{1, 3}, Balance: 0.3, Scores: []float64{0.2, 0.1}},
+			},
+			replace: approval.ApprovalVote{Choice: []int{3}, Balance: 0.7, Scores: []float64{0.35, 0.35}},
+			invalid: approval.ApprovalVote{Choice: []int{1, 1}, Balance: 1, Scores: []float64{0.5, 0.5}},
+		},
+		"weighted": {
+			full: func(votes []voting.Vote) tallierSystem {
+				v := &weighted.WeightedVoting{Choices: choices, Strategies: strategies}
+				for _, vote := range votes {
+					v.Votes = append(v.Votes, vote.(weighted.WeightedVote))
+				}
+				return v
+			},
+			votes: []voting.Vote{
+				weighted.WeightedVote{Choice: weighted.WeightedChoice{"1": 1, "2": 2}, Balance: 0.1, Scores: []float64{0.03, 0.07}},
+				weighted.WeightedVote{Choice: weighted.WeightedChoice{"2": 3}, Balance: 0.2, Scores: []float64{0.1, 0.1}},
+				weighted.WeightedVote{Choice: weighted.WeightedChoice{"1": 1, "3": 7}, Balance: 0.3, Scores: []float64{0.2, 0.1}},
+			},
+			replace: weighted.WeightedVote{Choice: weighted.WeightedChoice{"3": 1, "2": 3}, Balance: 0.7, Scores: []float64{0.35, 0.35}},
+			invalid: weighted.WeightedVote{Choice: weighted.WeightedChoice{"1": -1}, Balance: 1, Scores: []float64{0.5, 0.5}},
+		},
+		"quadratic": {
+			full: func(votes []voting.Vote) tallierSystem {
+				v := &quadratic.QuadraticVoting{Choices: choices, Strategies: strategies}
+				for _, vote := range votes {
+					v.Votes = append(v.Votes, vote.(quadratic.QuadraticVote))
+				}
+				return v
+			},
+			votes: []voting.Vote{
+				quadratic.QuadraticVote{Choice: quadratic.QuadraticChoice{"1": 1, "2": 2}, Balance: 0.1, Scores: []float64{0.03, 0.07}},
+				quadratic.QuadraticVote{Choice: quadratic.QuadraticChoice{"2": 3}, Balance: 0.2, Scores: []float64{0.1, 0.1}},
+				quadratic.QuadraticVote{Choice: quadratic.QuadraticChoice{"1": 1, "3": 3}, Balance: 0.3, Scores: []float64{0.2, 0.1}},
+			},
+			replace: quadratic.QuadraticVote{Choice: quadratic.QuadraticChoice{"3": 1, "2": 3}, Balance: 0.7, Scores: []float64{0.35, 0.35}},
+			invalid: quadratic.QuadraticVote{Choice: quadratic.QuadraticChoice{"5": 1}, Balance: 1, Scores: []float64{0.5, 0.5}},
+		},
+	}
+
+	compare := func(name, step string, tallier voting.Tallier, votes []voting.Vote, full func([]voting.Vote) tallierSystem) {
+		v := full(votes)
+		if tallier.GetScoresTotal() != v.GetScoresTotal() {
+			t.Errorf("%s %s: expected scores total %v, got %v", name, step, v.GetScoresTotal(), tallier.GetScoresTotal())
+		}
+		if !reflect.DeepEqual(tallier.GetScores(), v.GetScores()) {
+			t.Errorf("%s %s: expected scores %v, got %v", name, step, v.GetScores(), tallier.GetScores())
+		}
+		if !reflect.DeepEqual(tallier.GetScoresByStrategy(), v.GetScoresByStrategy()) {
+			t.Errorf("%s %s: expected scores by strategy %v, got %v", name, step, v.GetScoresByStrategy(), tallier.GetScoresByStrategy())
+		}
+	}
+
+	for name, system := range systems {
+		tallier := system.full(nil).NewTallier()
+		for _, vote := range system.votes {
+			if err := tallier.AddVote(vote); err != nil {
+				t.Fatalf("%s: expected vote %v to be added, got %v", name, vote, err)
+			}
+		}
+		compare(name, "add", tallier, system.votes, system.full)
+		compare(name, "seeded", system.full(system.votes).NewTallier(), system.votes, system.full)
+
+		if err := tallier.RemoveVote(system.votes[1]); err != nil {
+			t.Fatalf("%s: expected vote to be removed, got %v", name, err)
+		}
+		compare(name, "remove", tallier, []voting.Vote{system.votes[0], system.votes[2]}, system.full)
+
+		if err := tallier.ReplaceVote(system.votes[0], system.replace); err != nil {
+			t.Fatalf("%s: expected vote to be replaced, got %v", name, err)
+		}
+		compare(name, "replace", tallier, []voting.Vote{system.replace, system.votes[2]}, system.full)
+
+		if err := tallier.AddVote(system.invalid); err == nil {
+			t.Errorf("%s: expected invalid vote to be rejected", name)
+		}
+		if err := tallier.ReplaceVote(system.votes[2], system.invalid); err == nil {
+			t.Errorf("%s: expected replacement by invalid vote to be rejected", name)
+		}
+		compare(name, "rejected", tallier, []voting.Vote{system.replace, system.votes[2]}, system.full)
+
+		// A vote is removed at most as often as it was added.
+		if err := tallier.RemoveVote(system.votes[1]); voting.ErrorCodeOf(err) != voting.CodeVoteNotAdded {
+			t.Errorf("%s: expected removing a removed vote to fail with %s, got %v", name, voting.CodeVoteNotAdded, err)
+		}
+		if err := tallier.ReplaceVote(system.votes[0], system.votes[1]); voting.ErrorCodeOf(err) != voting.CodeVoteNotAdded {
+			t.Errorf("%s: expected replacing a replaced vote to fail with %s, got %v", name, voting.CodeVoteNotAdded, err)
+		}
+		compare(name, "not added", tallier, []voting.Vote{system.replace, system.votes[2]}, system.full)
+	}
+
+	// A tallier counts base units in the proposal's decimals, as the full
+	// count does.
+	units := func(n int64) *big.Int {
+		return new(big.Int).Mul(big.NewInt(n), new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil))
+	}
+	balanceUnits, scoresUnits := units(5), []*big.Int{units(2), units(3)}
+	for name, proposal := range map[string]tallierSystem{
+		"single-choice": &singleChoice.SingleChoiceVoting{Choices: choices, Strategies: strategies, Decimals: 18, Votes: []singleChoice.SingleChoiceVote{{Choice: 1, BalanceUnits: balanceUnits, ScoresUnits: scoresUnits}}},
+		"approval":      &approval.ApprovalVoting{Choices: choices, Strategies: strategies, Decimals: 18, Votes: []approval.ApprovalVote{{Choice: []int{1}, BalanceUnits: balanceUnits, ScoresUnits: scoresUnits}}},
+		"weighted":      &weighted.WeightedVoting{Choices: choices, Strategies: strategies, Decimals: 18, Votes: []weighted.WeightedVote{{Choice: weighted.WeightedChoice{"1": 1}, BalanceUnits: balanceUnits, ScoresUnits: scoresUnits}}},
+		"quadratic":     &quadratic.QuadraticVoting{Choices: choices, Strategies: strategies, Decimals: 18, Votes: []quadratic.QuadraticVote{{Choice: quadratic.QuadraticChoice{"1": 1}, BalanceUnits: balanceUnits, ScoresUnits: scoresUnits}}},
+	} {
+		if total := proposal.GetScoresTotal(); !utils.FloatEqual(total, 5) {
+			t.Errorf("%s: expected scores total 5, got %v", name, total)
+		}
+		tallier := proposal.NewTallier()
+		if !reflect.DeepEqual(tallier.GetScores(), proposal.GetScores()) || !reflect.DeepEqual(tallier.GetScoresByStrategy(), proposal.GetScoresByStrategy()) {
+			t.Errorf("%s: expected tallier scores %v, got %v", name, proposal.GetScores(), tallier.GetScores())
+		}
+		if tallier.GetScoresTotal() != proposal.GetScoresTotal() {
+			t.Errorf("%s: expected tallier scores total %v, got %v", name, proposal.GetScoresTotal(), tallier.GetScoresTotal())
+		}
+	}
+
+	// A vote is known by its contents, so a copy of an added vote removes it.
+	tallier := singleChoice.NewTallier(choices, strategies)
+	added := singleChoice.SingleChoiceVote{Choice: 1, BalanceUnits: units(5), ScoresUnits: []*big.Int{units(2), units(3)}}
+	if err := tallier.AddVote(added); err != nil {
+		t.Fatalf("Expected vote to be added, got %v", err)
+	}
+	if err := tallier.RemoveVote(singleChoice.SingleChoiceVote{Choice: 1, BalanceUnits: units(5), ScoresUnits: []*big.Int{units(2), units(3)}}); err != nil {
+		t.Errorf("Expected an equal vote to be removed, got %v", err)
+	}
+	if err := tallier.RemoveVote(added); voting.ErrorCodeOf(err) != voting.CodeVoteNotAdded {
+		t.Errorf("Expected a second removal to fail with %s, got %v", voting.CodeVoteNotAdded, err)
+	}
+}
+
+func TestSyncTallier(t *testing.T) {
+	choices := []string{"First", "Second", "Third"}
+	strategies := []voting.Strategy{{Name: "erc20-balance-of"}, {Name: "delegation"}}
+
+	singleChoiceVotes := []singleChoice.SingleChoiceVote{}
+	approvalVotes := []approval.ApprovalVote{}
+	weightedVotes := []weighted.WeightedVote{}
+	quadraticVotes := []quadratic.QuadraticVote{}
+	for i := 0; i < 200; i++ {
+		balance := float64(i%7) + 0.1
+		scores := []float64{balance / 3, balance - balance/3}
+		singleChoiceVotes = append(singleChoiceVotes, singleChoice.SingleChoiceVote{Choice: i%3 + 1, Balance: balance, Scores: scores})
+		approvalVotes = append(approvalVotes, approval.ApprovalVote{Choice: []int{i%3 + 1, (i+1)%3 + 1}, Balance: balance, Scores: scores})
+		weightedVotes = append(weightedVotes, weighted.WeightedVote{Choice: weighted.WeightedChoice{"1": i % 4, "3": 1}, Balance: balance, Scores: scores})
+		quadraticVotes = append(quadraticVotes, quadratic.QuadraticVote{Choice: quadratic.QuadraticChoice{"2": i%3 + 1, "3": 1}, Balance: balance, Scores: scores})
+	}
+
+	proposals := map[string]struct {
+		full    voting.VotingSystem
+		tallier voting.Tallier
+	}{
+		"single-choice": {
+			full:    &singleChoice.SingleChoiceVoting{Choices: choices, Strategies: strategies, Votes: singleChoiceVotes},
+			tallier: singleChoice.NewTallier(choices, strategies),
+		},
+		"approval": {
+			full:    &approval.ApprovalVoting{Choices: choices, Strategies: strategies, Votes: approvalVotes},
+			tallier: approval.NewTallier(choices, strategies),
+		},
+		"weighted": {
+			full:    &weighted.WeightedVoting{Choices: choices, Strategies: strategies, Votes: weightedVotes},
+			tallier: weighted.NewTallier(choices, strategies),
+		},
+		"quadratic": {
+			full:    &quadratic.QuadraticVoting{Choices: choices, Strategies: strategies, Votes: quadraticVotes},
+			tallier: quadratic.NewTallier(choices, strategies),
+		},
+	}
+
+	for name, proposal := range proposals {
+		tallier := voting.NewSyncTallier(proposal.tallier)
+		votes := proposal.full.GetVotes()
+		replaced := votes[0]
+
+		var wg sync.WaitGroup
+		for w := 0; w < 4; w++ {
+			wg.Add(1)
+			go func(w int) {
+				defer wg.Done()
+				for i := w; i < len(votes); i += 4 {
+					if err := tallier.AddVote(votes[i]); err != nil {
+						t.Errorf("%s: expected vote %d to be added, got %v", name, i, err)
+					}
+				}
+			}(w)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				snapshot := tallier.Snapshot()
+				if len(snapshot.Scores) != len(choices) || len(snapshot.ScoresByStrategy) != len(choices) {
+					t.Errorf("%s: expected a snapshot for %d choices, got %v", name, len(choices), snapshot)
+				}
+			}
+		}()
+		wg.Wait()
+
+		// Replacing a vote with itself concurrently must leave the tally as it was.
+		for w := 0; w < 4; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := tallier.ReplaceVote(replaced, replaced); err != nil {
+					t.Errorf("%s: expected vote to be replaced, got %v", name, err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		snapshot := tallier.Snapshot()
+		if snapshot.ScoresTotal != proposal.full.GetScoresTotal() {
+			t.Errorf("%s: expected scores total %v, got %v", name, proposal.full.GetScoresTotal(), snapshot.ScoresTotal)
+		}
+		if !reflect.DeepEqual(snapshot.Scores, proposal.full.GetScores()) {
+			t.Errorf("%s: expected scores %v, got %v", name, proposal.full.GetScores(), snapshot.Scores)
+		}
+		if !reflect.DeepEqual(snapshot.ScoresByStrategy, proposal.full.GetScoresByStrategy()) {
+			t.Errorf("%s: expected scores by strategy %v, got %v", name, proposal.full.GetScoresByStrategy(), snapshot.ScoresByStrategy)
+		}
+	}
+}
+
+func TestParallelTally(t *testing.T) {
+	choices := []string{"First", "Second", "Third", "Fourth"}
+	strategies := []voting.Strategy{{Name: "erc20-balance-of"}, {Name: "delegation"}}
+	random := rand.New(rand.NewSource(1))
+
+	singleChoiceVoting := &singleChoice.SingleChoiceVoting{Choices: choices, Strategies: strategies}
+	approvalVoting := &approval.ApprovalVoting{Choices: choices, Strategies: strategies}
+	weightedVoting := &weighted.WeightedVoting{Choices: choices, Strategies: strategies}
+	quadraticVoting := &quadratic.QuadraticVoting{Choices: choices, Strategies: strategies}
+	for i := 0; i < 5000; i++ {
+		first := random.Float64() * math.Pow(10, float64(random.Intn(12)))
+		second := random.Float64() * math.Pow(10, float64(random.Intn(12)))
+		balance := first + second
+		scores := []float64{first, second}
+		if i%97 == 0 {
+			balance++
+		}
+
+		weightedChoice := weighted.WeightedChoice{}
+		quadraticChoice := quadratic.QuadraticChoice{}
+		for j := range choices {
+			weightedChoice[strconv.Itoa(j+1)] = random.Intn(10)
+			quadraticChoice[strconv.Itoa(j+1)] = random.Intn(len(choices)) + 1
+		}
+
+		singleChoiceVoting.Votes = append(singleChoiceVoting.Votes, singleChoice.SingleChoiceVote{Choice: random.Intn(len(choices)+1) + 1, Balance: balance, Scores: scores})
+		approvalVoting.Votes = append(approvalVoting.Votes, approval.ApprovalVote{Choice: []int{i%len(choices) + 1, (i+2)%len(choices) + 1}, Balance: balance, Scores: scores})
+		weightedVoting.Votes = append(weightedVoting.Votes, weighted.WeightedVote{Choice: weightedChoice, Balance: balance, Scores: scores})
+		quadraticVoting.Votes = append(quadraticVoting.Votes, quadratic.QuadraticVote{Choice: quadraticChoice, Balance: balance, Scores: scores})
+	}
+
+	proposals := map[string]struct {
+		full     voting.VotingSystem
+		parallel func(workers int) voting.Tallier
+	}{
+		"single-choice": {singleChoiceVoting, func(workers int) voting.Tallier { return singleChoiceVoting.TallyParallel(workers) }},
+		"approval":      {approvalVoting, func(workers int) voting.Tallier { return approvalVoting.TallyParallel(workers) }},
+		"weighted":      {weightedVoting, func(workers int) voting.Tallier { return weightedVoting.TallyParallel(workers) }},
+		"quadratic":     {quadraticVoting, func(workers int) voting.Tallier { return quadraticVoting.TallyParallel(workers) }},
+	}
+
+	for name, proposal := range proposals {
+		expectedScores := proposal.full.GetScores()
+		expectedScoresByStrategy := proposal.full.GetScoresByStrategy()
+		for _, workers := range []int{0, 1, 3, 8, 10000} {
+			tallier := proposal.parallel(workers)
+			if tallier.GetScoresTotal() != proposal.full.GetScoresTotal() {
+				t.Errorf("%s with %d workers: expected scores total %v, got %v", name, workers, proposal.full.GetScoresTotal(), tallier.GetScoresTotal())
+			}
+			if !reflect.DeepEqual(tallier.GetScores(), expectedScores) {
+				t.Errorf("%s with %d workers: expected scores %v, got %v", name, workers, expectedScores, tallier.GetScores())
+			}
+			if !reflect.DeepEqual(tallier.GetScoresByStrategy(), expectedScoresByStrategy) {
+				t.Errorf("%s with %d workers: expected scores by strategy %v, got %v", name, workers, expectedScoresByStrategy, tallier.GetScoresByStrategy())
+			}
+		}
+	}
+
+	empty := &singleChoice.SingleChoiceVoting{Choices: choices, Strategies: strategies}
+	if scores := empty.TallyParallel(4).GetScores(); !reflect.DeepEqual(scores, empty.GetScores()) {
+		t.Errorf("Expected empty proposal scores %v, got %v", empty.GetScores(), scores)
+	}
+
+	// Every shard counts base units in the proposal's decimals.
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+	units := &singleChoice.SingleChoiceVoting{Choices: choices, Strategies: strategies, Decimals: 18}
+	for i := int64(1); i <= 4; i++ {
+		units.Votes = append(units.Votes, singleChoice.SingleChoiceVote{
+			Choice:       int(i),
+			BalanceUnits: new(big.Int).Mul(big.NewInt(i), unit),
+			ScoresUnits:  []*big.Int{new(big.Int).Mul(big.NewInt(i), unit), big.NewInt(0)},
+		})
+	}
+	for _, workers := range []int{1, 2} {
+		tallier := units.TallyParallel(workers)
+		if !reflect.DeepEqual(tallier.GetScores(), []float64{1, 2, 3, 4}) || tallier.GetScoresTotal() != units.GetScoresTotal() {
+			t.Errorf("Expected base-unit scores %v with %d workers, got %v", units.GetScores(), workers, tallier.GetScores())
+		}
+	}
+}
+
+func TestTallyStream(t *testing.T) {
+	proposal := &singleChoice.SingleChoiceVoting{
+		Choices:    []string{"First", "Second"},
+		Strategies: []voting.Strategy{{Name: "erc20-balance-of"}},
+		Votes: []singleChoice.SingleChoiceVote{
+			{Choice: 1, Balance: 0.1, Scores: []float64{0.1}},
+			{Choice: 2, Balance: 0.2, Scores: []float64{0.2}},
+			{Choice: 1, Balance: 0.3, Scores: []float64{0.3}},
+		},
+	}
+
+	inputs := map[string]string{
+		"array": ` [
+			{"choice": 1, "balance": 0.1, "scores": [0.1]},
+			{"choice": 2, "balance": 0.2, "scores": [0.2]},
+			{"choice": 3, "balance": 5, "scores": [5]},
+			{"choice": 1, "balance": 0.3, "scores": [0.3]}
+		]`,
+		"json-lines": `{"choice": 1, "balance": 0.1, "scores": [0.1]}
+{"choice": 2, "balance": 0.2, "scores": [0.2]}
+{"choice": 3, "balance": 5, "scores": [5]}
+{"choice": 1, "balance": 0.3, "scores": [0.3]}
+`,
+	}
+
+	for name, input := range inputs {
+		header := &singleChoice.SingleChoiceVoting{Choices: proposal.Choices, Strategies: proposal.Strategies}
+		tallier, stats, err := voting.TallyStream(header, strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("%s: expected votes to stream, got %v", name, err)
+		}
+
+		if stats.Votes != 4 || stats.Rejected != 1 {
+			t.Errorf("%s: expected 4 votes with 1 rejected, got %+v", name, stats)
+		}
+		if !reflect.DeepEqual(tallier.GetScores(), proposal.GetScores()) {
+			t.Errorf("%s: expected scores %v, got %v", name, proposal.GetScores(), tallier.GetScores())
+		}
+		if tallier.GetScoresTotal() != proposal.GetScoresTotal() {
+			t.Errorf("%s: expected scores total %v, got %v", name, proposal.GetScoresTotal(), tallier.GetScoresTotal())
+		}
+	}
+
+	header := &singleChoice.SingleChoiceVoting{Choices: proposal.Choices, Strategies: proposal.Strategies}
+	if _, stats, err := voting.TallyStream(header, strings.NewReader(`{"choice": 1, "balance": 1, "scores": [1]}
+{"choice": 1, "balance": `)); err == nil || stats.Votes != 1 {
+		t.Errorf("Expected an error after one vote of a truncated stream, got %+v, %v", stats, err)
+	}
+
+	if _, stats, err := voting.TallyStream(header, strings.NewReader("")); err != nil || stats.Votes != 0 {
+		t.Errorf("Expected an empty stream to tally nothing, got %+v, %v", stats, err)
+	}
+}
+
+func TestStrategy(t *testing.T) {
+	v := &approval.ApprovalVoting{}
+	err := json.Unmarshal([]byte(`{
+		"choices": ["First", "Second"],
+		"strategies": [
+			1,
+			"delegation",
+			{"name": "erc20-balance-of", "network": "1", "params": {"symbol": "DAI", "decimals": 18}, "multiplier": 2, "label": "DAI"},
+			{"name": "erc721"},
+			{}
+		],
+		"votes": [
+			{"choice": [1], "balance": 15, "scores": [1, 2, 3, 4, 5]},
+			{"choice": [1, 2], "balance": 5, "scores": [1, 1, 1, 1, 1]}
+		]
+	}`), v)
+	if err != nil {
+		t.Fatalf("Expected proposal to decode, got %v", err)
+	}
+
+	expected := []voting.Strategy{
+		{Name: "1"},
+		{Name: "delegation"},
+		{Name: "erc20-balance-of", Network: "1", Params: map[string]interface{}{"symbol": "DAI", "decimals": float64(18)}, Multiplier: 2, Label: "DAI"},
+		{Name: "erc721"},
+		{},
+	}
+	if !reflect.DeepEqual(v.GetStrategies(), expected) {
+		t.Errorf("Expected strategies %+v, got %+v", expected, v.GetStrategies())
+	}
+
+	expectedLabels := []string{"1", "delegation", "DAI", "erc721", "strategy 5"}
+	result := voting.NewResult(v)
+	if !reflect.DeepEqual(result.Strategies, expectedLabels) {
+		t.Errorf("Expected strategy labels %v, got %v", expectedLabels, result.Strategies)
+	}
+
+	labelled := voting.LabelScoresByStrategy(v.GetStrategies(), v.GetScoresByStrategy())
+	if labelled[0]["DAI"] != 8 || labelled[1]["strategy 5"] != 1 {
+		t.Errorf("Expected scores keyed by strategy label, got %v", labelled)
+	}
+
+	duplicated := voting.LabelScoresByStrategy([]voting.Strategy{{Name: "erc20"}, {Name: "erc20"}}, [][]float64{{1, 2}})
+	if duplicated[0]["erc20 #1"] != 1 || duplicated[0]["erc20 #2"] != 2 {
+		t.Errorf("Expected repeated labels to be numbered, got %v", duplicated)
+	}
+
+	encoded, err := json.Marshal(voting.Strategy{Name: "erc20-balance-of", Label: "Token"})
+	if err != nil || string(encoded) != `{"name":"erc20-balance-of","label":"Token"}` {
+		t.Errorf("Expected strategy to encode without empty fields, got %s, %v", encoded, err)
+	}
+
+	var malformed voting.Strategy
+	if err := json.Unmarshal([]byte(`{"name": 1}`), &malformed); err == nil {
+		t.Errorf("Expected a strategy with a numeric name to fail to decode")
+	}
+
+	// null is a no-op, so it neither names a strategy "null" nor clears one.
+	var nulls []voting.Strategy
+	if err := json.Unmarshal([]byte(`[null, "delegation"]`), &nulls); err != nil || !reflect.DeepEqual(nulls, []voting.Strategy{{}, {Name: "delegation"}}) {
+		t.Errorf("Expected null to decode to an empty strategy, got %+v, %v", nulls, err)
+	}
+	kept := voting.Strategy{Name: "erc721"}
+	if err := json.Unmarshal([]byte(`null`), &kept); err != nil || kept.Name != "erc721" {
+		t.Errorf("Expected null to leave the strategy unchanged, got %+v, %v", kept, err)
+	}
+}
+
+func TestEffectivePower(t *testing.T) {
+	choices := []string{"First", "Second"}
+	strategies := []voting.Strategy{
+		{Name: "erc20-balance-of"},
+		{Name: "erc721", Multiplier: 2},
+		{Name: "delegation", Cap: 10},
+	}
+
+	balance, scores := voting.EffectivePower(strategies, 30, []float64{5, 5, 20})
+	if balance != 25 || !reflect.DeepEqual(scores, []float64{5, 10, 10}) {
+		t.Errorf("Expected power 25 from %v, got %v from %v", []float64{5, 10, 10}, balance, scores)
+	}
+
+	unadjusted := []voting.Strategy{{Name: "erc20-balance-of"}, {Name: "erc721", Multiplier: 1}}
+	if balance, scores := voting.EffectivePower(unadjusted, 7, []float64{3, 3}); balance != 7 || !reflect.DeepEqual(scores, []float64{3, 3}) {
+		t.Errorf("Expected balance and scores to be left alone, got %v and %v", balance, scores)
+	}
+
+	votes := []struct {
+		choice  int
+		balance float64
+		scores  []float64
+	}{
+		{1, 30, []float64{5, 5, 20}},
+		{2, 9, []float64{1, 4, 4}},
+		{1, 3, []float64{1, 1, 1}},
+	}
+	expectedTotal := float64(25 + 13 + 4)
+	expectedScores := []float64{29, 13}
+	expectedScoresByStrategy := [][]float64{{6, 12, 11}, {1, 8, 4}}
+
+	singleChoiceVoting := &singleChoice.SingleChoiceVoting{Choices: choices, Strategies: strategies}
+	approvalVoting := &approval.ApprovalVoting{Choices: choices, Strategies: strategies}
+	weightedVoting := &weighted.WeightedVoting{Choices: choices, Strategies: strategies}
+	quadraticVoting := &quadratic.QuadraticVoting{Choices: choices, Strategies: strategies}
+	for _, vote := range votes {
+		key := strconv.Itoa(vote.choice)
+		singleChoiceVoting.Votes = append(singleChoiceVoting.Votes, singleChoice.SingleChoiceVote{Choice: vote.choice, Balance: vote.balance, Scores: vote.scores})
+		approvalVoting.Votes = append(approvalVoting.Votes, approval.ApprovalVote{Choice: []int{vote.choice}, Balance: vote.balance, Scores: vote.scores})
+		weightedVoting.Votes = append(weightedVoting.Votes, weighted.WeightedVote{Choice: weighted.WeightedChoice{key: 1}, Balance: vote.balance, Scores: vote.scores})
+		quadraticVoting.Votes = append(quadraticVoting.Votes, quadratic.QuadraticVote{Choice: quadratic.QuadraticChoice{key: 1}, Balance: vote.balance, Scores: vote.scores})
+	}
+
+	proposals := map[string]struct {
+		full    voting.ExactVotingSystem
+		tallier voting.Tallier
+	}{
+		"single-choice": {singleChoiceVoting, singleChoiceVoting.NewTallier()},
+		"approval":      {approvalVoting, approvalVoting.NewTallier()},
+		"weighted":      {weightedVoting, weightedVoting.NewTallier()},
+		"quadratic":     {quadraticVoting, quadraticVoting.NewTallier()},
+	}
+
+	for name, proposal := range proposals {
+		if total := proposal.full.GetScoresTotal(); !utils.FloatEqual(total, expectedTotal) {
+			t.Errorf("%s: expected scores total %v, got %v", name, expectedTotal, total)
+		}
+
+		// Quadratic squares the summed roots of each choice's power:
+		// (√25 + √4)² = 49 against √13² = 13, scaled to the total of 42.
+		expected := expectedScores
+		if name == "quadratic" {
+			expected = []float64{expectedTotal * 49 / 62, expectedTotal * 13 / 62}
+		}
+		for i, score := range proposal.full.GetScores() {
+			if !utils.FloatEqual(score, expected[i]) {
+				t.Errorf("%s: expected score %v for choice %d, got %v", name, expected[i], i+1, score)
+			}
+		}
+
+		if name == "single-choice" || name == "approval" {
+			if !reflect.DeepEqual(proposal.full.GetScoresByStrategy(), expectedScoresByStrategy) {
+				t.Errorf("%s: expected scores by strategy %v, got %v", name, expectedScoresByStrategy, proposal.full.GetScoresByStrategy())
+			}
+		}
+
+		if !reflect.DeepEqual(proposal.tallier.GetScores(), proposal.full.GetScores()) {
+			t.Errorf("%s: expected tallier scores %v, got %v", name, proposal.full.GetScores(), proposal.tallier.GetScores())
+		}
+		if !reflect.DeepEqual(proposal.tallier.GetScoresByStrategy(), proposal.full.GetScoresByStrategy()) {
+			t.Errorf("%s: expected tallier scores by strategy %v, got %v", name, proposal.full.GetScoresByStrategy(), proposal.tallier.GetScoresByStrategy())
+		}
+
+		if total := proposal.full.GetScoresTotalExact(); total.Cmp(big.NewRat(42, 1)) != 0 {
+			t.Errorf("%s: expected exact scores total 42, got %s", name, total.RatString())
+		}
+	}
+
+	units := &singleChoice.SingleChoiceVoting{
+		Choices:    choices,
+		Strategies: []voting.Strategy{{Name: "erc20-balance-of", Multiplier: 1.5}, {Name: "delegation", Cap: 0.5}},
+		Decimals:   0,
+		Votes: []singleChoice.SingleChoiceVote{
+			{Choice: 1, BalanceUnits: big.NewInt(8), ScoresUnits: []*big.Int{big.NewInt(5), big.NewInt(3)}},
+		},
+	}
+	if scores := units.GetScoresByStrategyUnits(); scores[0][0].Int64() != 7 || scores[0][1].Int64() != 0 {
+		t.Errorf("Expected adjusted units rounded toward zero, got %v", scores)
+	}
+	if total := units.GetScoresTotalUnits(); total.Int64() != 7 {
+		t.Errorf("Expected units total 7, got %s", total)
+	}
+
+	// A negative or non-finite multiplier or cap rejects every vote rather
+	// than handing out negative power.
+	for _, strategy := range []voting.Strategy{
+		{Name: "erc721", Multiplier: -1},
+		{Name: "erc721", Multiplier: math.NaN()},
+		{Name: "erc721", Cap: -10},
+		{Name: "erc721", Cap: math.Inf(1)},
+	} {
+		if err := strategy.Validate(); voting.ErrorCodeOf(err) != voting.CodeInvalidStrategy {
+			t.Errorf("Expected code %s for %+v, got %v", voting.CodeInvalidStrategy, strategy, err)
+		}
+
+		broken := []voting.Strategy{{Name: "erc20-balance-of"}, strategy}
+		singleChoiceVoting.Strategies = broken
+		approvalVoting.Strategies = broken
+		scoreVoting := &score.ScoreVoting{Choices: choices, Strategies: broken, Votes: []score.ScoreVote{{Choice: score.ScoreChoice{"1": 5}, Balance: 2, Scores: []float64{1, 1}}}}
+		for name, proposal := range map[string]voting.VotingSystem{"single-choice": singleChoiceVoting, "approval": approvalVoting, "score": scoreVoting} {
+			if err := proposal.ValidateVote(proposal.GetVotes()[0]); voting.ErrorCodeOf(err) != voting.CodeInvalidStrategy {
+				t.Errorf("%s: expected code %s for %+v, got %v", name, voting.CodeInvalidStrategy, strategy, err)
+			}
+			if total := proposal.GetScoresTotal(); total != 0 {
+				t.Errorf("%s: expected no scores total for %+v, got %v", name, strategy, total)
+			}
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package weighted
+
+import (
+	"math/big"
+	"strconv"
+
+	"github.com/This-Is-Prince/votingSystemGo/utils"
+	"github.com/This-Is-Prince/votingSystemGo/voting"
+)
+
+var _ voting.ExactVotingSystem = (*WeightedVoting)(nil)
+
+func (v WeightedVote) GetExactBalance(decimals int) *big.Rat {
+	return utils.ExactValue(v.BalanceUnits, v.ExactBalance, v.Balance, decimals)
+}
+
+func (v WeightedVote) GetExactScores(decimals int) []*big.Rat {
+	return utils.ExactValues(v.ScoresUnits, v.ExactScores, v.Scores, decimals)
+}
+
+func WeightedPowerExact(choice int, choices WeightedChoice, balance *big.Rat) *big.Rat {
+	whole := int64(0)
+	for _, c := range choices {
+		whole = whole + int64(c)
+	}
+
+	if choice == 0 || whole == 0 {
+		return new(big.Rat)
+	}
+
+	percentage := big.NewRat(int64(choice), whole)
+	return percentage.Mul(percentage, balance)
+}
+
+func (v *WeightedVoting) GetScoresTotalExact() *big.Rat {
+	scoresTotal := new(big.Rat)
+	for _, vote := range v.GetValidVotes() {
+		balance, _ := v.exactPower(vote)
+		scoresTotal.Add(scoresTotal, balance)
+	}
+	return scoresTotal
+}
+
+func (v *WeightedVoting) GetScoresExact() []*big.Rat {
+	scoresTotal := new(big.Rat)
+	scores := utils.NewRats(len(v.Choices))
+
+	for _, vote := range v.GetValidVotes() {
+		balance, _ := v.exactPower(vote)
+		scoresTotal.Add(scoresTotal, balance)
+		for idx, value := range vote.Choice {
+			index, _ := strconv.Atoi(idx)
+			scores[index-1].Add(scores[index-1], WeightedPowerExact(value, vote.Choice, balance))
+		}
+	}
+
+	percentageOfScores := []*big.Rat{}
+	for _, score := range scores {
+		percentageOfScores = append(percentageOfScores, utils.CalcPercentageOfSumRat(score, scores))
+	}
+
+	return utils.CalcReducedQuadraticScoresRat(scoresTotal, percentageOfScores)
+}
+
+func (v *WeightedVoting) GetScoresByStrategyExact() [][]*big.Rat {
+	scoresTotal := new(big.Rat)
+	scoresByStrategy := utils.NewRatMatrix(len(v.Choices), len(v.Strategies))
+
+	for _, vote := range v.GetValidVotes() {
+		balance, scores := v.exactPower(vote)
+		scoresTotal.Add(scoresTotal, balance)
+		for idx, value := range vote.Choice {
+			index, _ := strconv.Atoi(idx)
+			for sIdx, score := range scores {
+				scoresByStrategy[index-1][sIdx].Add(scoresByStrategy[index-1][sIdx], WeightedPowerExact(value, vote.Choice, score))
+			}
+		}
+	}
+
+	flattenScoresByStrategy := []*big.Rat{}
+	for _, scores := range scoresByStrategy {
+		flattenScoresByStrategy = append(flattenScoresByStrategy, scores...)
+	}
+
+	for idx, scores := range scoresByStrategy {
+		percentageOfScores := []*big.Rat{}
+		for _, score := range scores {
+			percentageOfScores = append(percentageOfScores, utils.CalcPercentageOfSumRat(score, flattenScoresByStrategy))
+		}
+		scoresByStrategy[idx] = utils.CalcReducedQuadraticScoresRat(scoresTotal, percentageOfScores)
+	}
+
+	return scoresByStrategy
+}
+
+func (v *WeightedVoting) exactPower(vote WeightedVote) (*big.Rat, []*big.Rat) {
+	return voting.EffectivePowerExact(v.Strategies, vote.GetExactBalance(v.Decimals), vote.GetExactScores(v.Decimals))
+}
@@ -0,0 +1,89 @@
+package weighted
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/This-Is-Prince/votingSystemGo/utils"
+	"github.com/This-Is-Prince/votingSystemGo/voting"
+)
+
+// Tallier keeps running weighted scores, so adding, removing or
+// replacing a vote costs O(choices × strategies) and the scores match a full
+// tally of the same votes.
+type Tallier struct {
+	voting.RunningTally[WeightedVote]
+}
+
+var _ voting.Tallier = (*Tallier)(nil)
+
+var _ voting.Streamer = (*WeightedVoting)(nil)
+
+func NewTallier(choices []string, strategies []voting.Strategy) *Tallier {
+	return (&WeightedVoting{Choices: choices, Strategies: strategies}).newTallier()
+}
+
+// newTallier returns an empty Tallier that counts votes as the proposal
+// does, in its decimals.
+func (v *WeightedVoting) newTallier() *Tallier {
+	proposal := *v
+	proposal.Votes = nil
+	return &Tallier{voting.NewRunningTally(len(proposal.Choices), len(proposal.Strategies), proposal.ValidateVote, proposal.add)}
+}
+
+// NewTallier returns a Tallier seeded with the valid votes of the proposal.
+func (v *WeightedVoting) NewTallier() voting.Tallier {
+	t := v.newTallier()
+	for _, vote := range v.GetValidVotes() {
+		t.Count(vote)
+	}
+	return t
+}
+
+// DecodeVote reads the next weighted vote from dec.
+func (v *WeightedVoting) DecodeVote(dec *json.Decoder) (voting.Vote, error) {
+	var vote WeightedVote
+	if err := dec.Decode(&vote); err != nil {
+		return nil, err
+	}
+	return vote, nil
+}
+
+// TallyParallel tallies the valid votes of the proposal on the given number of
+// workers and returns the merged Tallier. The scores match GetScores and
+// GetScoresByStrategy exactly.
+func (v *WeightedVoting) TallyParallel(workers int) *Tallier {
+	return voting.ParallelTally(v.Votes, workers, func() *Tallier {
+		return v.newTallier()
+	}, func(t *Tallier, vote WeightedVote) {
+		if v.validateVote(vote) == nil {
+			t.Count(vote)
+		}
+	}, (*Tallier).Merge)
+}
+
+// Merge adds the votes tallied by other into t.
+func (t *Tallier) Merge(other *Tallier) {
+	t.RunningTally.Merge(&other.RunningTally)
+}
+
+func (t *Tallier) GetScores() []float64 {
+	return normalizeScores(t.ScoresTotal.Float64(), utils.AccumulatorsToFloats(t.Scores))
+}
+
+func (t *Tallier) GetScoresByStrategy() [][]float64 {
+	return normalizeScoresByStrategy(t.ScoresTotal.Float64(), utils.AccumulatorMatrixToFloats(t.ScoresByStrategy))
+}
+
+func (v *WeightedVoting) add(t *voting.RunningTally[WeightedVote], vote WeightedVote, sign float64) {
+	balance, scores := v.power(vote)
+	t.ScoresTotal.Add(sign * balance)
+	keys, choices := sortedChoice(vote.Choice)
+	for i, idx := range keys {
+		index, _ := strconv.Atoi(idx)
+		t.Scores[index-1].Add(sign * WeightedPower(choices[i], choices, balance))
+		for sIdx, score := range scores {
+			t.ScoresByStrategy[index-1][sIdx].Add(sign * WeightedPower(choices[i], choices, score))
+		}
+	}
+}
@@ -2,48 +2,86 @@ package weighted
 
 import (
 	"log"
+	"math/big"
 	"strconv"
 
-	"github.com/thoas/go-funk"
-
 	"github.com/This-Is-Prince/votingSystemGo/utils"
+	"github.com/This-Is-Prince/votingSystemGo/voting"
 )
 
 type WeightedVote struct {
-	Choice  WeightedChoice `json:"choice"`
-	Balance float64        `json:"balance"`
-	Scores  []float64      `json:"scores"`
+	Choice       WeightedChoice `json:"choice"`
+	Balance      float64        `json:"balance"`
+	Scores       []float64      `json:"scores"`
+	ExactBalance *big.Rat       `json:"exactBalance,omitempty"`
+	ExactScores  []*big.Rat     `json:"exactScores,omitempty"`
+	BalanceUnits *big.Int       `json:"balanceUnits,omitempty"`
+	ScoresUnits  []*big.Int     `json:"scoresUnits,omitempty"`
+	Voter        string         `json:"voter,omitempty"`
+	Created      int64          `json:"created,omitempty"`
 }
 
 type WeightedChoice map[string]int
 
 type WeightedVoting struct {
-	Choices    []string       `json:"choices"`
-	Votes      []WeightedVote `json:"votes"`
-	Strategies []interface{}  `json:"strategies"`
+	Choices    []string          `json:"choices"`
+	Votes      []WeightedVote    `json:"votes"`
+	Strategies []voting.Strategy `json:"strategies"`
+	Decimals   int               `json:"decimals,omitempty"`
 }
 
-func IsValidChoice(voteChoice WeightedChoice, proposalChoices []string) bool {
+var _ voting.VotingSystem = (*WeightedVoting)(nil)
+
+func (v WeightedVote) GetChoice() interface{} {
+	return v.Choice
+}
+
+func (v WeightedVote) GetBalance() float64 {
+	return v.Balance
+}
+
+func (v WeightedVote) GetScores() []float64 {
+	return v.Scores
+}
+
+func (v WeightedVote) GetVoter() string {
+	return v.Voter
+}
+
+func (v WeightedVote) GetCreated() int64 {
+	return v.Created
+}
+
+func (v WeightedVote) Supports(choice int) bool {
+	return v.Choice[strconv.Itoa(choice)] > 0
+}
+
+func ValidateChoice(voteChoice WeightedChoice, proposalChoices []string) error {
 	if voteChoice == nil || len(voteChoice) == 0 {
-		return false
+		return voting.NewValidationError(voting.CodeEmptyChoice, "no choice is weighted")
 	}
 
-	for k, v := range voteChoice {
+	for _, k := range utils.SortedChoiceKeys(voteChoice) {
+		v := voteChoice[k]
 		if v < 0 {
-			return false
+			return voting.NewValidationError(voting.CodeNegativeWeight, "choice %s has negative weight %d", k, v)
 		}
 
 		numKey, err := strconv.ParseInt(k, 10, 64)
 		if err != nil {
-			return false
+			return voting.NewValidationError(voting.CodeInvalidChoiceKey, "choice %q is not a number", k)
 		}
 
 		if numKey <= 0 || int(numKey) > len(proposalChoices) {
-			return false
+			return voting.NewValidationError(voting.CodeChoiceOutOfRange, "choice %d is not between 1 and %d", numKey, len(proposalChoices))
 		}
 	}
 
-	return true
+	return nil
+}
+
+func IsValidChoice(voteChoice WeightedChoice, proposalChoices []string) bool {
+	return ValidateChoice(voteChoice, proposalChoices) == nil
 }
 
 func WeightedPower(choice float64, choices []float64, balance float64) float64 {
@@ -51,95 +89,157 @@ func WeightedPower(choice float64, choices []float64, balance float64) float64 {
 	return percentage * balance
 }
 
+func (v *WeightedVoting) GetChoices() []string {
+	return v.Choices
+}
+
+func (v *WeightedVoting) GetStrategies() []voting.Strategy {
+	return v.Strategies
+}
+
+func (v *WeightedVoting) GetDecimals() int {
+	return v.Decimals
+}
+
+func (v *WeightedVoting) GetVotes() []voting.Vote {
+	return voting.ToVotes(v.Votes)
+}
+
+func (v *WeightedVoting) Deduplicate(policy voting.DedupPolicy) []voting.Vote {
+	votes, superseded := voting.Deduplicate(v.Votes, policy)
+	v.Votes = votes
+	return voting.ToVotes(superseded)
+}
+
+func (v *WeightedVoting) IsValidVote(vote voting.Vote) bool {
+	return v.ValidateVote(vote) == nil
+}
+
+func (v *WeightedVoting) ValidateVote(vote voting.Vote) error {
+	weightedVote, ok := vote.(WeightedVote)
+	if !ok {
+		return voting.NewValidationError(voting.CodeInvalidVoteType, "expected %T, got %T", WeightedVote{}, vote)
+	}
+
+	return v.validateVote(weightedVote)
+}
+
+func (v *WeightedVoting) validateVote(vote WeightedVote) error {
+	if err := voting.ValidateStrategies(v.Strategies); err != nil {
+		return err
+	}
+
+	if err := ValidateChoice(vote.Choice, v.Choices); err != nil {
+		return err
+	}
+
+	return voting.ValidateAmounts(vote.Balance, vote.Scores, vote.ExactBalance, vote.ExactScores, vote.BalanceUnits, vote.ScoresUnits, len(v.Strategies))
+}
+
+func (v *WeightedVoting) power(vote WeightedVote) (float64, []float64) {
+	balance, scores := voting.FloatAmounts(vote.Balance, vote.Scores, vote.ExactBalance, vote.ExactScores, vote.BalanceUnits, vote.ScoresUnits, v.Decimals)
+	return voting.EffectivePower(v.Strategies, balance, scores)
+}
+
 func (v *WeightedVoting) GetValidVotes() []WeightedVote {
-	return funk.Filter(v.Votes, func(vote WeightedVote) bool {
-		return IsValidChoice(vote.Choice, v.Choices)
-	}).([]WeightedVote)
+	return utils.Filter(v.Votes, func(vote WeightedVote) bool {
+		return v.validateVote(vote) == nil
+	})
 }
 
 func (v *WeightedVoting) GetScoresTotal() float64 {
-	return funk.Reduce(v.Votes, func(acc float64, vote WeightedVote) float64 {
-		return acc + vote.Balance
-	}, 0).(float64)
+	scoresTotal := utils.Accumulator{}
+	for _, vote := range v.GetValidVotes() {
+		balance, _ := v.power(vote)
+		scoresTotal.Add(balance)
+	}
+	return scoresTotal.Float64()
 }
 
-func (v *WeightedVoting) GetScores() []float64 {
-	scoresTotal := 0.0
-	scores := []float64{}
-
-	for range v.Choices {
-		scores = append(scores, 0.0)
+// sortedChoice returns the keys of a vote's choice in numeric order and
+// their weights, so every tally walks a vote in the same order.
+func sortedChoice(voteChoice WeightedChoice) ([]string, []float64) {
+	keys := utils.SortedChoiceKeys(voteChoice)
+	choices := []float64{}
+	for _, k := range keys {
+		choices = append(choices, float64(voteChoice[k]))
 	}
+	return keys, choices
+}
+
+// GetScores sums votes exactly, so the scores are bit-identical whatever the
+// order of the votes.
+func (v *WeightedVoting) GetScores() []float64 {
+	scoresTotal := utils.Accumulator{}
+	scoreSums := make([]utils.Accumulator, len(v.Choices))
 
 	for _, vote := range v.Votes {
-		if IsValidChoice(vote.Choice, v.Choices) {
-			scoresTotal = scoresTotal + vote.Balance
-			choices := []float64{}
-			for _, v := range vote.Choice {
-				choices = append(choices, (float64(v)))
-			}
+		if v.validateVote(vote) == nil {
+			balance, _ := v.power(vote)
+			scoresTotal.Add(balance)
+			keys, choices := sortedChoice(vote.Choice)
 
-			for idx, value := range vote.Choice {
-				choiceWeightedPower := WeightedPower((float64(value)), choices, vote.Balance)
+			for i, idx := range keys {
+				choiceWeightedPower := WeightedPower(choices[i], choices, balance)
 				index, err := strconv.ParseInt(idx, 10, 64)
 				if err != nil {
 					log.Println("Error while parsing string:-", err)
 					continue
 				}
-				scores[index-1] = scores[index-1] + choiceWeightedPower
+				scoreSums[index-1].Add(choiceWeightedPower)
 			}
 
 		}
 	}
 
-	percentageOfScores := []float64{}
-	for _, score := range scores {
-		percentageOfScores = append(percentageOfScores, utils.CalcPercentageOfSum(score, scores))
-	}
-	newScores := utils.CalcReducedQuadraticScores(scoresTotal, percentageOfScores)
-
-	return newScores
+	return normalizeScores(scoresTotal.Float64(), utils.AccumulatorsToFloats(scoreSums))
 }
 
 func (v *WeightedVoting) GetScoresByStrategy() [][]float64 {
-	scoresTotal := 0.0
-	scoresByStrategy := [][]float64{}
+	scoresTotal := utils.Accumulator{}
+	scoreSumsByStrategy := [][]utils.Accumulator{}
 
 	for range v.Choices {
-		scores := []float64{}
-		for range v.Strategies {
-			scores = append(scores, 0.0)
-		}
-		scoresByStrategy = append(scoresByStrategy, scores)
+		scoreSumsByStrategy = append(scoreSumsByStrategy, make([]utils.Accumulator, len(v.Strategies)))
 	}
 
 	for _, vote := range v.Votes {
-		if IsValidChoice(vote.Choice, v.Choices) {
-			scoresTotal = (scoresTotal + vote.Balance)
-			choices := []float64{}
-			for _, v := range vote.Choice {
-				choices = append(choices, (float64(v)))
-			}
-			for idx, value := range vote.Choice {
+		if v.validateVote(vote) == nil {
+			balance, scores := v.power(vote)
+			scoresTotal.Add(balance)
+			keys, choices := sortedChoice(vote.Choice)
+			for i, idx := range keys {
 				index, err := strconv.ParseInt(idx, 10, 64)
 				if err != nil {
 					log.Println("Error while parsing string:-", err)
 					continue
 				}
-				for sIdx, score := range vote.Scores {
-					choiceWeightedPower := WeightedPower((float64(value)), choices, score)
-					scoresByStrategy[index-1][sIdx] = scoresByStrategy[index-1][sIdx] + choiceWeightedPower
+				for sIdx, score := range scores {
+					choiceWeightedPower := WeightedPower(choices[i], choices, score)
+					scoreSumsByStrategy[index-1][sIdx].Add(choiceWeightedPower)
 				}
 			}
 		}
 	}
 
-	flattenScoresByStrategy := funk.FlattenDeep(scoresByStrategy)
+	return normalizeScoresByStrategy(scoresTotal.Float64(), utils.AccumulatorMatrixToFloats(scoreSumsByStrategy))
+}
+
+func normalizeScores(scoresTotal float64, scores []float64) []float64 {
+	percentageOfScores := []float64{}
+	for _, score := range scores {
+		percentageOfScores = append(percentageOfScores, utils.CalcPercentageOfSum(score, scores))
+	}
+	return utils.CalcReducedQuadraticScores(scoresTotal, percentageOfScores)
+}
+
+func normalizeScoresByStrategy(scoresTotal float64, scoresByStrategy [][]float64) [][]float64 {
+	flattenScoresByStrategy := utils.Flatten(scoresByStrategy)
 
 	for idx, scores := range scoresByStrategy {
 		percentageOfScores := []float64{}
 		for _, score := range scores {
-			percentageOfScores = append(percentageOfScores, utils.CalcPercentageOfSum((score), flattenScoresByStrategy.([]float64)))
+			percentageOfScores = append(percentageOfScores, utils.CalcPercentageOfSum((score), flattenScoresByStrategy))
 		}
 		scoresByStrategy[idx] = utils.CalcReducedQuadraticScores(scoresTotal, percentageOfScores)
 	}
@@ -1,9 +1,14 @@
 package weighted
 
 import (
+	"math"
+	"math/rand"
+	"reflect"
+	"strconv"
 	"testing"
 
 	"github.com/This-Is-Prince/votingSystemGo/utils"
+	"github.com/This-Is-Prince/votingSystemGo/voting"
 )
 
 func TestWeightedVoting(t *testing.T) {
@@ -60,7 +65,7 @@ func TestWeightedVoting(t *testing.T) {
 	quadraticVoting := WeightedVoting{
 		Choices:    choices,
 		Votes:      votes,
-		Strategies: []interface{}{1, 2},
+		Strategies: []voting.Strategy{{Name: "erc20-balance-of"}, {Name: "delegation"}},
 	}
 
 	validVotes := quadraticVoting.GetValidVotes()
@@ -111,3 +116,52 @@ func TestWeightedVoting(t *testing.T) {
 		}
 	}
 }
+
+func TestWeightedVotingDeterministic(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	choices := []string{"First", "Second", "Third", "Fourth", "Fifth"}
+	votes := []WeightedVote{}
+	for i := 0; i < 200; i++ {
+		choice := WeightedChoice{}
+		for j := range choices {
+			choice[strconv.Itoa(j+1)] = random.Intn(10)
+		}
+		choice["1"]++
+		first := random.Float64() * math.Pow(10, float64(random.Intn(12)))
+		second := random.Float64() * math.Pow(10, float64(random.Intn(12)))
+		votes = append(votes, WeightedVote{
+			Choice:  choice,
+			Balance: first + second,
+			Scores:  []float64{first, second},
+		})
+	}
+
+	weightedVoting := WeightedVoting{
+		Choices:    choices,
+		Votes:      votes,
+		Strategies: []voting.Strategy{{Name: "erc20-balance-of"}, {Name: "delegation"}},
+	}
+	expectedScoresTotal := weightedVoting.GetScoresTotal()
+	expectedScores := weightedVoting.GetScores()
+	expectedScoresByStrategy := weightedVoting.GetScoresByStrategy()
+
+	for i := 0; i < 20; i++ {
+		shuffled := append([]WeightedVote{}, votes...)
+		random.Shuffle(len(shuffled), func(i, j int) {
+			shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+		})
+		weightedVoting.Votes = shuffled
+
+		if scoresTotal := weightedVoting.GetScoresTotal(); scoresTotal != expectedScoresTotal {
+			t.Fatalf("Expected scores total %v regardless of order, got %v", expectedScoresTotal, scoresTotal)
+		}
+
+		if scores := weightedVoting.GetScores(); !reflect.DeepEqual(scores, expectedScores) {
+			t.Fatalf("Expected scores %v regardless of order, got %v", expectedScores, scores)
+		}
+
+		if scoresByStrategy := weightedVoting.GetScoresByStrategy(); !reflect.DeepEqual(scoresByStrategy, expectedScoresByStrategy) {
+			t.Fatalf("Expected scores by strategy %v regardless of order, got %v", expectedScoresByStrategy, scoresByStrategy)
+		}
+	}
+}